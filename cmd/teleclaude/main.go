@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
@@ -9,14 +11,107 @@ import (
 	"time"
 
 	"github.com/user/teleclaude/internal/approval"
+	"github.com/user/teleclaude/internal/auth"
 	"github.com/user/teleclaude/internal/config"
 	"github.com/user/teleclaude/internal/session"
+	"github.com/user/teleclaude/internal/session/badger"
 	"github.com/user/teleclaude/internal/telegram"
+	"github.com/user/teleclaude/internal/transport"
+	"github.com/user/teleclaude/internal/voice"
+	"github.com/user/teleclaude/internal/xmpp"
 )
 
+// newSessionStore builds the SessionStore selected by cfg.Storage.Backend.
+// dataDir is only used by the "file" and "badger" backends.
+func newSessionStore(cfg config.StorageConfig, dataDir string) (session.SessionStore, error) {
+	switch cfg.Backend {
+	case "", "file":
+		return session.NewStorage(dataDir), nil
+	case "badger":
+		return badger.New(filepath.Join(dataDir, cfg.Path))
+	case "consul":
+		if len(cfg.Endpoints) == 0 {
+			return nil, fmt.Errorf("storage.backend=consul requires at least one endpoint")
+		}
+		return session.NewConsulStore(cfg.Endpoints[0], cfg.Prefix, &session.TLSConfig{
+			Enabled:  cfg.TLS.Enabled,
+			CAFile:   cfg.TLS.CAFile,
+			CertFile: cfg.TLS.CertFile,
+			KeyFile:  cfg.TLS.KeyFile,
+		})
+	case "etcd":
+		if len(cfg.Endpoints) == 0 {
+			return nil, fmt.Errorf("storage.backend=etcd requires at least one endpoint")
+		}
+		return session.NewEtcdStore(cfg.Endpoints, cfg.Prefix, &session.TLSConfig{
+			Enabled:  cfg.TLS.Enabled,
+			CAFile:   cfg.TLS.CAFile,
+			CertFile: cfg.TLS.CertFile,
+			KeyFile:  cfg.TLS.KeyFile,
+		})
+	default:
+		return nil, fmt.Errorf("unknown storage.backend %q", cfg.Backend)
+	}
+}
+
+// newApprovalStore builds the RequestStore selected by cfg.Backend, the
+// approval.Persist counterpart to newSessionStore. It doesn't offer a
+// "badger" backend since nothing has asked for an embedded option here yet.
+func newApprovalStore(cfg config.StorageConfig, dataDir string) (approval.RequestStore, error) {
+	switch cfg.Backend {
+	case "", "file":
+		return approval.NewFileStore(dataDir), nil
+	case "consul":
+		if len(cfg.Endpoints) == 0 {
+			return nil, fmt.Errorf("approval.storage.backend=consul requires at least one endpoint")
+		}
+		return approval.NewConsulStore(cfg.Endpoints[0], cfg.Prefix, &session.TLSConfig{
+			Enabled:  cfg.TLS.Enabled,
+			CAFile:   cfg.TLS.CAFile,
+			CertFile: cfg.TLS.CertFile,
+			KeyFile:  cfg.TLS.KeyFile,
+		})
+	case "etcd":
+		if len(cfg.Endpoints) == 0 {
+			return nil, fmt.Errorf("approval.storage.backend=etcd requires at least one endpoint")
+		}
+		return approval.NewEtcdStore(cfg.Endpoints, cfg.Prefix, &session.TLSConfig{
+			Enabled:  cfg.TLS.Enabled,
+			CAFile:   cfg.TLS.CAFile,
+			CertFile: cfg.TLS.CertFile,
+			KeyFile:  cfg.TLS.KeyFile,
+		})
+	default:
+		return nil, fmt.Errorf("unknown approval.storage.backend %q", cfg.Backend)
+	}
+}
+
+// configPathAndDataDir resolves the config file path and data directory the
+// same way for both the bot and its migrate-sessions subcommand.
+func configPathAndDataDir() (configPath, dataDir string) {
+	configPath = os.Getenv("TELECLAUDE_CONFIG")
+	home, _ := os.UserHomeDir()
+	if configPath == "" {
+		configPath = filepath.Join(home, ".teleclaude", "config.yaml")
+	}
+	return configPath, filepath.Join(home, ".teleclaude")
+}
+
 func main() {
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate-sessions" {
+		configPath, dataDir := configPathAndDataDir()
+		cfg, err := config.Load(configPath)
+		if err != nil {
+			log.Fatalf("Failed to load config from %s: %v", configPath, err)
+		}
+		if err := runMigrateSessions(cfg, dataDir); err != nil {
+			log.Fatalf("migrate-sessions failed: %v", err)
+		}
+		return
+	}
+
 	// Get token from env
 	token := os.Getenv("TELEGRAM_BOT_TOKEN")
 	if token == "" {
@@ -24,11 +119,7 @@ func main() {
 	}
 
 	// Determine config path
-	configPath := os.Getenv("TELECLAUDE_CONFIG")
-	if configPath == "" {
-		home, _ := os.UserHomeDir()
-		configPath = filepath.Join(home, ".teleclaude", "config.yaml")
-	}
+	configPath, dataDir := configPathAndDataDir()
 
 	// Load config
 	cfg, err := config.Load(configPath)
@@ -36,25 +127,100 @@ func main() {
 		log.Fatalf("Failed to load config from %s: %v", configPath, err)
 	}
 
-	if len(cfg.AllowedUsers) == 0 {
+	if len(cfg.Users) == 0 {
 		log.Fatal("No allowed users configured. Add your Telegram user ID to config.")
 	}
 
 	// Initialize components
-	home, _ := os.UserHomeDir()
-	dataDir := filepath.Join(home, ".teleclaude")
-
-	storage := session.NewStorage(dataDir)
+	storage, err := newSessionStore(cfg.Storage, dataDir)
+	if err != nil {
+		log.Fatalf("Failed to initialize session storage: %v", err)
+	}
 	sessionMgr := session.NewManager(storage)
+	sessionMgr.SetSessionDefaults(cfg.Sessions.TTL.Duration, cfg.Sessions.RenewInterval.Duration)
+
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	defer stopWatch()
+	sessionMgr.WatchForUpdates(watchCtx)
+
 	approvalWf := approval.NewWorkflow(5 * time.Minute)
 	formatter := telegram.NewFormatter(cfg.Streaming.ChunkSize)
+	formatter.SetStreamWorkers(cfg.Streaming.Workers)
+	formatter.SetPartSize(cfg.Streaming.PartSize)
+
+	bans, err := auth.NewBanList(filepath.Join(dataDir, "bans.yaml"))
+	if err != nil {
+		log.Fatalf("Failed to load ban list: %v", err)
+	}
 
 	// Create bot
-	bot, err := telegram.NewBot(token, cfg, sessionMgr, approvalWf, formatter)
+	bot, err := telegram.NewBot(token, cfg, sessionMgr, approvalWf, formatter, bans)
 	if err != nil {
 		log.Fatalf("Failed to create bot: %v", err)
 	}
 
+	sessionMgr.StartJanitor(watchCtx, cfg.Sessions.RenewInterval.Duration, cfg.Sessions.Retention.Duration, bot.IsSessionRunning)
+	sessionMgr.SetOnIdle(bot.TeardownRuntime)
+
+	if err := bot.WatchConfigFile(watchCtx, configPath); err != nil {
+		log.Printf("Config hot-reload disabled: %v", err)
+	}
+
+	if cfg.Approval.Persist {
+		approvalStore, err := newApprovalStore(cfg.Approval.Storage, dataDir)
+		if err != nil {
+			log.Fatalf("Failed to initialize approval storage: %v", err)
+		}
+		pending, err := approvalWf.LoadPending(approvalStore)
+		if err != nil {
+			log.Printf("Failed to load pending approvals: %v", err)
+		} else if len(pending) > 0 {
+			log.Printf("Replaying %d pending approval(s) from before restart", len(pending))
+			bot.RepostPendingApprovals(pending)
+		}
+	}
+
+	if cfg.Voice.Enabled {
+		bot.SetVoice(
+			voice.NewWhisperTranscriber(voice.WhisperConfig{
+				BinPath:    cfg.Voice.WhisperBin,
+				ModelPath:  cfg.Voice.WhisperModel,
+				FFmpegPath: cfg.Voice.FFmpegBin,
+			}),
+			voice.NewPiperSynthesizer(voice.PiperConfig{
+				BinPath:    cfg.Voice.PiperBin,
+				ModelPath:  cfg.Voice.PiperModel,
+				FFmpegPath: cfg.Voice.FFmpegBin,
+			}),
+		)
+	}
+
+	var xmppChat *xmpp.Chat
+	if cfg.HasTransport("xmpp") {
+		xmppChat, err = xmpp.New(xmpp.Config{
+			JID:      cfg.XMPP.JID,
+			Password: cfg.XMPP.Password,
+			Host:     cfg.XMPP.Host,
+			Port:     cfg.XMPP.Port,
+		})
+		if err != nil {
+			log.Fatalf("Failed to create XMPP transport: %v", err)
+		}
+
+		// Full command/session-driving parity with the Telegram transport
+		// isn't wired up yet; for now the XMPP side just acknowledges
+		// messages so the connection and approval prompt path can be
+		// exercised end to end.
+		xmppChat.OnUserMessage(func(msg transport.UserMessage) {
+			xmppChat.SendMessage(msg.Recipient, "XMPP command support is not implemented yet; use the Telegram bot for now.")
+		})
+		go func() {
+			if err := xmppChat.Start(); err != nil {
+				log.Printf("XMPP transport stopped: %v", err)
+			}
+		}()
+	}
+
 	// Handle shutdown
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
@@ -62,12 +228,16 @@ func main() {
 	go func() {
 		<-sigChan
 		log.Println("Shutdown signal received")
+		stopWatch()
 		sessionMgr.MarkAllIdle()
+		if xmppChat != nil {
+			xmppChat.Stop()
+		}
 		bot.Stop()
 		os.Exit(0)
 	}()
 
 	// Start bot
-	log.Printf("TeleClaude starting with %d allowed users", len(cfg.AllowedUsers))
+	log.Printf("TeleClaude starting with %d allowed users", len(cfg.Users))
 	bot.Start()
 }
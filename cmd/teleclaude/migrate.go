@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/user/teleclaude/internal/config"
+	"github.com/user/teleclaude/internal/session"
+	"github.com/user/teleclaude/internal/session/badger"
+)
+
+// runMigrateSessions reads every session out of the YAML file store at
+// dataDir and writes it into a BadgerDB store at cfg.Storage.Path, so an
+// operator can switch storage.backend to "badger" without losing existing
+// session history. Invoked as `teleclaude migrate-sessions`.
+func runMigrateSessions(cfg *config.Config, dataDir string) error {
+	yamlStore := session.NewStorage(dataDir)
+
+	badgerDir := filepath.Join(dataDir, cfg.Storage.Path)
+	badgerStore, err := badger.New(badgerDir)
+	if err != nil {
+		return fmt.Errorf("opening badger store at %s: %w", badgerDir, err)
+	}
+	defer badgerStore.Close()
+
+	sessions, err := yamlStore.ListAll()
+	if err != nil {
+		return fmt.Errorf("reading yaml sessions: %w", err)
+	}
+
+	for _, sess := range sessions {
+		if err := badgerStore.Save(sess); err != nil {
+			return fmt.Errorf("migrating session %s: %w", sess.ID, err)
+		}
+	}
+
+	log.Printf("migrate-sessions: migrated %d sessions from %s into %s", len(sessions), dataDir, badgerDir)
+	return nil
+}
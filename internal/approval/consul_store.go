@@ -0,0 +1,98 @@
+package approval
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/user/teleclaude/internal/session"
+	"gopkg.in/yaml.v3"
+)
+
+// ConsulStore persists pending approval requests under prefix/<id> in
+// Consul's KV store, the same layout session.ConsulStore uses for sessions,
+// so multiple bot replicas behind a load balancer share pending-approval
+// state instead of each only knowing about the requests it created.
+type ConsulStore struct {
+	client *api.Client
+	prefix string
+}
+
+// NewConsulStore dials the Consul agent at addr and returns a store that
+// keys entries under prefix (e.g. "teleclaude/approvals").
+func NewConsulStore(addr, prefix string, tls *session.TLSConfig) (*ConsulStore, error) {
+	cfg := api.DefaultConfig()
+	cfg.Address = addr
+	if tls != nil && tls.Enabled {
+		cfg.TLSConfig = api.TLSConfig{
+			CAFile:   tls.CAFile,
+			CertFile: tls.CertFile,
+			KeyFile:  tls.KeyFile,
+		}
+	}
+
+	client, err := api.NewClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("consul: failed to create client: %w", err)
+	}
+
+	return &ConsulStore{client: client, prefix: strings.Trim(prefix, "/")}, nil
+}
+
+var _ RequestStore = (*ConsulStore)(nil)
+
+func (c *ConsulStore) key(id string) string {
+	return c.prefix + "/" + id
+}
+
+func (c *ConsulStore) Save(req *StoredRequest) error {
+	data, err := yaml.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	kv := c.client.KV()
+	_, err = kv.Put(&api.KVPair{Key: c.key(req.ID), Value: data}, nil)
+	return err
+}
+
+func (c *ConsulStore) Load(id string) (*StoredRequest, error) {
+	kv := c.client.KV()
+	pair, _, err := kv.Get(c.key(id), nil)
+	if err != nil {
+		return nil, err
+	}
+	if pair == nil {
+		return nil, fmt.Errorf("consul: approval request %s not found", id)
+	}
+
+	req := &StoredRequest{}
+	if err := yaml.Unmarshal(pair.Value, req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func (c *ConsulStore) Delete(id string) error {
+	kv := c.client.KV()
+	_, err := kv.Delete(c.key(id), nil)
+	return err
+}
+
+func (c *ConsulStore) ListPending() ([]*StoredRequest, error) {
+	kv := c.client.KV()
+	pairs, _, err := kv.List(c.prefix+"/", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []*StoredRequest
+	for _, pair := range pairs {
+		req := &StoredRequest{}
+		if err := yaml.Unmarshal(pair.Value, req); err != nil {
+			continue // Skip corrupted entries, same as FileStore.ListPending
+		}
+		pending = append(pending, req)
+	}
+	return pending, nil
+}
@@ -0,0 +1,464 @@
+package approval
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/user/teleclaude/internal/claude"
+	"github.com/user/teleclaude/internal/session"
+)
+
+// Action is the disposition a Rule (or a session.ApprovalOverride) assigns
+// to a matching tool-use message.
+type Action string
+
+const (
+	ActionRequire   Action = "require"
+	ActionAutoAllow Action = "auto_allow"
+	ActionAutoDeny  Action = "auto_deny"
+)
+
+// Outcome is what Engine.Evaluate decided a tool-use message should do.
+type Outcome string
+
+const (
+	OutcomeAllow Outcome = "allow"
+	OutcomeDeny  Outcome = "deny"
+	OutcomeAsk   Outcome = "ask"
+)
+
+// Match narrows which tool-use messages a Rule applies to. A blank field is
+// a wildcard: an empty Tool matches any tool, an empty CommandRegex matches
+// any command, an empty CwdGlob matches any project path.
+type Match struct {
+	Tool         string `yaml:"tool"`
+	CommandRegex string `yaml:"command_regex"`
+	CwdGlob      string `yaml:"cwd_glob"`
+
+	// Network restricts a rule to sessions sandboxed with the given
+	// runtime/docker --network value (e.g. "none" or "bridge"), read off
+	// session.Session.NetworkMode. Blank matches any session, sandboxed or
+	// not. This lets an operator write a rule like
+	// {tool: Bash, command_regex: "rm\\s", network: none, action: auto_allow}
+	// to waive approval for a command that's only dangerous with network
+	// access, once it's confined to a network-isolated container.
+	Network string `yaml:"network"`
+
+	// UserID restricts a rule to sessions owned by the given
+	// session.Session.TransportUserID. Blank matches any user. config's
+	// applyDefaults sets this when it flattens a UserEntry's
+	// ApprovalOverrides into the global rule list, so one user's standing
+	// override (e.g. always require approval for Write) never applies to
+	// anyone else's session.
+	UserID string `yaml:"user_id"`
+}
+
+// Rule is one entry of the approval_rules: config list. Rules are
+// evaluated in order; the first enabled rule whose Match applies decides
+// the message's Action.
+type Rule struct {
+	Name   string `yaml:"name"`
+	Match  Match  `yaml:"match"`
+	Action Action `yaml:"action"`
+	TTL    string `yaml:"ttl"` // optional; not yet consumed, reserved for time-boxed auto_allow/auto_deny rules
+}
+
+// Decision is the result of evaluating one tool-use message: whether it's
+// allowed to proceed, denied outright, or needs a human to decide, plus
+// which rule (if any) decided it, for audit logging.
+type Decision struct {
+	Outcome  Outcome
+	RuleName string // matched rule's name, or "override:<name>" for a session.ApprovalOverride; "" if nothing matched
+	Tool     string
+	Reason   string
+	Command  string
+}
+
+// compiledRule is a Rule with its regex/glob patterns compiled once at
+// Engine construction, rather than on every Evaluate call.
+type compiledRule struct {
+	rule    Rule
+	cmdRe   *regexp.Regexp
+	cwdRe   *regexp.Regexp
+	enabled bool
+}
+
+func (cr *compiledRule) matches(tool, command, cwd, network, userID string) bool {
+	if !cr.enabled {
+		return false
+	}
+	if cr.rule.Match.Tool != "" && !strings.EqualFold(cr.rule.Match.Tool, tool) {
+		return false
+	}
+	if cr.cmdRe != nil && !cr.cmdRe.MatchString(command) {
+		return false
+	}
+	if cr.cwdRe != nil && !cr.cwdRe.MatchString(cwd) {
+		return false
+	}
+	if cr.rule.Match.Network != "" && !strings.EqualFold(cr.rule.Match.Network, network) {
+		return false
+	}
+	if cr.rule.Match.UserID != "" && cr.rule.Match.UserID != userID {
+		return false
+	}
+	return true
+}
+
+// ruleLabel identifies a rule for audit logging and /policy when it has no
+// explicit Name.
+func ruleLabel(r Rule) string {
+	if r.Name != "" {
+		return r.Name
+	}
+	label := r.Match.Tool
+	if r.Match.CommandRegex != "" {
+		if label != "" {
+			label += ":"
+		}
+		label += r.Match.CommandRegex
+	}
+	if label == "" {
+		label = string(r.Action)
+	}
+	return label
+}
+
+// globToRegexp compiles a shell glob into a regular expression. Unlike
+// path/filepath.Match, ** matches across path separators, so a cwd_glob
+// like "/home/*/work/**" can describe an entire project tree rather than
+// just its immediate children.
+func globToRegexp(pattern string) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i := 0; i < len(pattern); {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**"):
+			sb.WriteString(".*")
+			i += 2
+		case pattern[i] == '*':
+			sb.WriteString("[^/]*")
+			i++
+		case pattern[i] == '?':
+			sb.WriteString(".")
+			i++
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(pattern[i])))
+			i++
+		}
+	}
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
+
+// Engine evaluates tool-use messages against a compiled rule set plus any
+// per-session overrides, replacing Rules' hardcoded keyword list with a
+// regex/glob rule DSL loaded from config.
+type Engine struct {
+	mu          sync.RWMutex
+	rules       []compiledRule
+	policies    []compiledPolicy
+	adminBypass bool
+}
+
+// NewEngine compiles rules once, failing fast on an invalid command_regex
+// or cwd_glob rather than at evaluation time. Admin bypass is on by
+// default; disable it with SetAdminBypass(false) for deployments where
+// even admins should follow the rule set.
+func NewEngine(rules []Rule) (*Engine, error) {
+	compiled, err := compileRules(rules)
+	if err != nil {
+		return nil, err
+	}
+	return &Engine{rules: compiled, adminBypass: true}, nil
+}
+
+// compileRules compiles a Rule list into compiledRules, shared by NewEngine
+// and ReplaceRules so both fail on the same invalid command_regex/cwd_glob
+// rather than at evaluation time.
+func compileRules(rules []Rule) ([]compiledRule, error) {
+	compiled := make([]compiledRule, 0, len(rules))
+	for _, r := range rules {
+		cr := compiledRule{rule: r, enabled: true}
+
+		if r.Match.CommandRegex != "" {
+			re, err := regexp.Compile(r.Match.CommandRegex)
+			if err != nil {
+				return nil, fmt.Errorf("approval: rule %q: invalid command_regex: %w", ruleLabel(r), err)
+			}
+			cr.cmdRe = re
+		}
+
+		if r.Match.CwdGlob != "" {
+			re, err := globToRegexp(r.Match.CwdGlob)
+			if err != nil {
+				return nil, fmt.Errorf("approval: rule %q: invalid cwd_glob: %w", ruleLabel(r), err)
+			}
+			cr.cwdRe = re
+		}
+
+		compiled = append(compiled, cr)
+	}
+	return compiled, nil
+}
+
+// ValidateRules reports whether rules would compile as an engine's rule
+// set, without installing them anywhere. Used by config.Watcher to check
+// a reloaded config's approval_rules before swapping the config in, so an
+// invalid command_regex/cwd_glob fails the whole reload atomically instead
+// of going live with a config the approval engine can't actually follow.
+func ValidateRules(rules []Rule) error {
+	_, err := compileRules(rules)
+	return err
+}
+
+// ValidatePolicies is ValidateRules' counterpart for approval.policies.
+func ValidatePolicies(policies []Policy) error {
+	_, err := compilePolicies(policies)
+	return err
+}
+
+// SetAdminBypass toggles whether a session whose Role is RoleAdmin skips
+// the rule set entirely (the default) or is evaluated normally.
+func (e *Engine) SetAdminBypass(enabled bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.adminBypass = enabled
+}
+
+// ReplaceRules recompiles the rule set from rules and atomically swaps it
+// in, leaving the previous rules (and any SetRuleEnabled/SetAdminBypass
+// state) in place if compilation fails. Used by config.Watcher's reload
+// path so editing approval_rules in config.yaml takes effect without a bot
+// restart.
+func (e *Engine) ReplaceRules(rules []Rule) error {
+	compiled, err := compileRules(rules)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	e.rules = compiled
+	e.mu.Unlock()
+	return nil
+}
+
+// SetPolicies compiles and installs the shell-aware approval.policies
+// list Evaluate checks before falling back to the Match/Action rule list
+// for Bash commands. Mirrors ReplaceRules: a compile failure (an invalid
+// arg_regex) leaves the previous policy set in place.
+func (e *Engine) SetPolicies(policies []Policy) error {
+	compiled, err := compilePolicies(policies)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	e.policies = compiled
+	e.mu.Unlock()
+	return nil
+}
+
+// Evaluate decides what should happen to a tool-use message: admin bypass,
+// then a session override, then (for Bash) the shell-aware policies list,
+// take precedence over the global rule set, which is checked last; anything
+// unmatched is allowed to proceed.
+func (e *Engine) Evaluate(msg *claude.Message, sess *session.Session) Decision {
+	reason := extractReason(msg)
+	command := extractCommand(msg)
+
+	decision := Decision{Outcome: OutcomeAllow, Tool: msg.ToolName, Reason: reason, Command: command}
+
+	if msg.Type != claude.MessageTypeToolUse {
+		return decision
+	}
+
+	if sess != nil && sess.Role == string(RoleAdmin) {
+		e.mu.RLock()
+		bypass := e.adminBypass
+		e.mu.RUnlock()
+		if bypass {
+			decision.RuleName = "admin-bypass"
+			return decision
+		}
+	}
+
+	if sess != nil {
+		if override, ok := sess.FindApprovalOverride(msg.ToolName, command); ok {
+			decision.Outcome = outcomeForAction(override.Action)
+			decision.RuleName = "override:" + override.Name
+			return decision
+		}
+	}
+
+	if msg.ToolName == "Bash" {
+		e.mu.RLock()
+		policies := e.policies
+		e.mu.RUnlock()
+		if policyDecision, ok := e.evaluateShellPolicy(command, policies); ok {
+			policyDecision.Tool, policyDecision.Reason, policyDecision.Command = msg.ToolName, reason, command
+			return policyDecision
+		}
+	}
+
+	var cwd, network, userID string
+	if sess != nil {
+		cwd = sess.ProjectPath
+		network = sess.NetworkMode
+		userID = sess.TransportUserID
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	for _, cr := range e.rules {
+		if !cr.matches(msg.ToolName, command, cwd, network, userID) {
+			continue
+		}
+		decision.Outcome = outcomeForAction(string(cr.rule.Action))
+		decision.RuleName = ruleLabel(cr.rule)
+		return decision
+	}
+
+	return decision
+}
+
+func outcomeForAction(action string) Outcome {
+	switch Action(action) {
+	case ActionAutoAllow:
+		return OutcomeAllow
+	case ActionAutoDeny:
+		return OutcomeDeny
+	default:
+		return OutcomeAsk
+	}
+}
+
+// RuleStatus summarizes one compiled rule for display, e.g. by /policy.
+type RuleStatus struct {
+	Name    string
+	Action  Action
+	Enabled bool
+}
+
+// ListRules reports every compiled rule's current enabled state, in
+// evaluation order.
+func (e *Engine) ListRules() []RuleStatus {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	statuses := make([]RuleStatus, len(e.rules))
+	for i, cr := range e.rules {
+		statuses[i] = RuleStatus{Name: ruleLabel(cr.rule), Action: cr.rule.Action, Enabled: cr.enabled}
+	}
+	return statuses
+}
+
+// SetRuleEnabled toggles a rule on or off at runtime by name, so an admin
+// can silence a noisy rule with /policy without editing config and
+// restarting the bot.
+func (e *Engine) SetRuleEnabled(name string, enabled bool) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for i := range e.rules {
+		if ruleLabel(e.rules[i].rule) == name {
+			e.rules[i].enabled = enabled
+			return nil
+		}
+	}
+	return fmt.Errorf("approval: no rule named %q", name)
+}
+
+// DefaultDangerousCommandRules is the substring/regex-over-raw-command
+// pattern list the original hardcoded isDangerousCommand check used. It's
+// no longer seeded by config.applyDefaults — DefaultDangerousCommandPolicies
+// covers the same Bash commands without the false positives a raw-string
+// regex produces (e.g. matching "echo rm is scary") — but it's kept
+// available for a config that wants that simpler, coarser matching on
+// purpose.
+func DefaultDangerousCommandRules() []Rule {
+	patterns := []string{
+		`rm\s`, `rmdir`, `git push`, `git force`, `--force`, `-f\s`,
+		`sudo\s`, `chmod\s`, `chown\s`, `>\s*/`, `\|\s*sudo`, `dd\s`, `mkfs`, `fdisk`, `format`,
+	}
+
+	rules := make([]Rule, 0, len(patterns))
+	for _, p := range patterns {
+		rules = append(rules, Rule{
+			Name:   "dangerous-bash:" + p,
+			Match:  Match{Tool: "Bash", CommandRegex: "(?i)" + p},
+			Action: ActionRequire,
+		})
+	}
+	return rules
+}
+
+// extractReason and extractCommand derive a human-readable reason and the
+// command text from a tool-use message, so Evaluate stays a single entry
+// point instead of requiring callers to re-derive them separately.
+func extractReason(msg *claude.Message) string {
+	if msg.ToolName == "Bash" {
+		var input struct {
+			Command     string `json:"command"`
+			Description string `json:"description"`
+		}
+		if err := json.Unmarshal(msg.ToolInput, &input); err == nil {
+			if input.Description != "" {
+				return input.Description
+			}
+			return describeCommand(input.Command)
+		}
+	}
+	return "Execute " + msg.ToolName + " operation"
+}
+
+func extractCommand(msg *claude.Message) string {
+	if msg.ToolName == "Bash" {
+		var input claude.BashInput
+		if err := json.Unmarshal(msg.ToolInput, &input); err == nil {
+			return input.Command
+		}
+	}
+	return string(msg.ToolInput)
+}
+
+func describeCommand(cmd string) string {
+	cmd = strings.TrimSpace(cmd)
+	parts := strings.Fields(cmd)
+	if len(parts) == 0 {
+		return "Run shell command"
+	}
+
+	switch parts[0] {
+	case "rm", "rmdir":
+		return "Delete files/directories"
+	case "git":
+		if len(parts) > 1 {
+			return "Git " + parts[1] + " operation"
+		}
+		return "Git operation"
+	case "go":
+		if len(parts) > 1 {
+			return "Go " + parts[1]
+		}
+		return "Go command"
+	case "npm", "yarn", "pnpm":
+		if len(parts) > 1 {
+			return parts[0] + " " + parts[1]
+		}
+		return parts[0] + " command"
+	default:
+		return "Run: " + truncate(cmd, 30)
+	}
+}
+
+func truncate(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	return s[:maxLen-3] + "..."
+}
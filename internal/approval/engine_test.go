@@ -0,0 +1,259 @@
+package approval
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/user/teleclaude/internal/claude"
+	"github.com/user/teleclaude/internal/session"
+)
+
+func bashMsg(command string) *claude.Message {
+	input, _ := json.Marshal(claude.BashInput{Command: command})
+	return &claude.Message{Type: claude.MessageTypeToolUse, ToolName: "Bash", ToolInput: input}
+}
+
+func TestEngineEvaluateRequire(t *testing.T) {
+	engine, err := NewEngine([]Rule{
+		{Name: "bash-require", Match: Match{Tool: "Bash"}, Action: ActionRequire},
+	})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+
+	decision := engine.Evaluate(bashMsg("ls -la"), nil)
+	if decision.Outcome != OutcomeAsk {
+		t.Errorf("expected OutcomeAsk, got %v", decision.Outcome)
+	}
+	if decision.RuleName != "bash-require" {
+		t.Errorf("expected RuleName bash-require, got %q", decision.RuleName)
+	}
+}
+
+func TestEngineEvaluateAutoAllow(t *testing.T) {
+	engine, err := NewEngine([]Rule{
+		{Name: "allow-go-test", Match: Match{Tool: "Bash", CommandRegex: `^go test`}, Action: ActionAutoAllow},
+	})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+
+	decision := engine.Evaluate(bashMsg("go test ./..."), nil)
+	if decision.Outcome != OutcomeAllow {
+		t.Errorf("expected OutcomeAllow, got %v", decision.Outcome)
+	}
+}
+
+func TestEngineEvaluateAutoDeny(t *testing.T) {
+	engine, err := NewEngine([]Rule{
+		{Name: "deny-rm", Match: Match{Tool: "Bash", CommandRegex: `rm -rf`}, Action: ActionAutoDeny},
+	})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+
+	decision := engine.Evaluate(bashMsg("rm -rf /tmp/x"), nil)
+	if decision.Outcome != OutcomeDeny {
+		t.Errorf("expected OutcomeDeny, got %v", decision.Outcome)
+	}
+}
+
+func TestEngineEvaluateNoMatchAllows(t *testing.T) {
+	engine, err := NewEngine([]Rule{
+		{Name: "bash-require", Match: Match{Tool: "Bash", CommandRegex: "git push"}, Action: ActionRequire},
+	})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+
+	decision := engine.Evaluate(bashMsg("ls -la"), nil)
+	if decision.Outcome != OutcomeAllow {
+		t.Errorf("expected OutcomeAllow, got %v", decision.Outcome)
+	}
+}
+
+func TestEngineEvaluateCwdGlob(t *testing.T) {
+	engine, err := NewEngine([]Rule{
+		{Name: "scoped", Match: Match{Tool: "Bash", CwdGlob: "/home/*/work/**"}, Action: ActionRequire},
+	})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+
+	inside := &session.Session{ProjectPath: "/home/alice/work/teleclaude"}
+	outside := &session.Session{ProjectPath: "/home/alice/personal"}
+
+	if d := engine.Evaluate(bashMsg("ls"), inside); d.Outcome != OutcomeAsk {
+		t.Errorf("expected OutcomeAsk for path inside glob, got %v", d.Outcome)
+	}
+	if d := engine.Evaluate(bashMsg("ls"), outside); d.Outcome != OutcomeAllow {
+		t.Errorf("expected OutcomeAllow for path outside glob, got %v", d.Outcome)
+	}
+}
+
+func TestEngineEvaluateNetworkMatch(t *testing.T) {
+	engine, err := NewEngine([]Rule{
+		{Name: "sandboxed-rm", Match: Match{Tool: "Bash", CommandRegex: `rm\s`, Network: "none"}, Action: ActionAutoAllow},
+		{Name: "bash-require", Match: Match{Tool: "Bash"}, Action: ActionRequire},
+	})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+
+	sandboxed := &session.Session{ContainerID: "abc123", NetworkMode: "none"}
+	open := &session.Session{ContainerID: "abc123", NetworkMode: "bridge"}
+
+	if d := engine.Evaluate(bashMsg("rm -rf ./tmp"), sandboxed); d.Outcome != OutcomeAllow {
+		t.Errorf("expected OutcomeAllow for rm inside a --network=none sandbox, got %v", d.Outcome)
+	}
+	if d := engine.Evaluate(bashMsg("rm -rf ./tmp"), open); d.Outcome != OutcomeAsk {
+		t.Errorf("expected OutcomeAsk for rm outside a --network=none sandbox, got %v", d.Outcome)
+	}
+}
+
+func TestEngineEvaluateUserIDMatch(t *testing.T) {
+	engine, err := NewEngine([]Rule{
+		{Name: "alice-write", Match: Match{Tool: "Write", UserID: "111"}, Action: ActionRequire},
+	})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+
+	msg := &claude.Message{Type: claude.MessageTypeToolUse, ToolName: "Write", ToolInput: []byte(`{}`)}
+
+	alice := &session.Session{TransportUserID: "111"}
+	bob := &session.Session{TransportUserID: "222"}
+
+	if d := engine.Evaluate(msg, alice); d.Outcome != OutcomeAsk {
+		t.Errorf("expected OutcomeAsk for alice's own override, got %v", d.Outcome)
+	}
+	if d := engine.Evaluate(msg, bob); d.Outcome != OutcomeAllow {
+		t.Errorf("expected OutcomeAllow for a user the override doesn't name, got %v", d.Outcome)
+	}
+}
+
+func TestEngineEvaluateAdminBypass(t *testing.T) {
+	engine, err := NewEngine([]Rule{
+		{Name: "bash-require", Match: Match{Tool: "Bash"}, Action: ActionRequire},
+	})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+
+	admin := &session.Session{Role: string(RoleAdmin)}
+	operator := &session.Session{Role: string(RoleOperator)}
+
+	if d := engine.Evaluate(bashMsg("rm -rf /"), admin); d.Outcome != OutcomeAllow {
+		t.Errorf("expected admin bypass to allow, got %v", d.Outcome)
+	}
+	if d := engine.Evaluate(bashMsg("rm -rf /"), operator); d.Outcome != OutcomeAsk {
+		t.Errorf("expected operator to still hit the rule, got %v", d.Outcome)
+	}
+
+	engine.SetAdminBypass(false)
+	if d := engine.Evaluate(bashMsg("rm -rf /"), admin); d.Outcome != OutcomeAsk {
+		t.Errorf("expected admin to follow rules once bypass is disabled, got %v", d.Outcome)
+	}
+}
+
+func TestEngineSessionOverrideTakesPrecedence(t *testing.T) {
+	engine, err := NewEngine([]Rule{
+		{Name: "bash-require", Match: Match{Tool: "Bash"}, Action: ActionRequire},
+	})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+
+	sess := &session.Session{
+		ApprovalOverrides: []session.ApprovalOverride{
+			{Name: "allow-go-test", Tool: "Bash", CommandHasPrefix: "go test", Action: "auto_allow"},
+		},
+	}
+
+	decision := engine.Evaluate(bashMsg("go test ./..."), sess)
+	if decision.Outcome != OutcomeAllow {
+		t.Errorf("expected override to auto-allow, got %v", decision.Outcome)
+	}
+	if decision.RuleName != "override:allow-go-test" {
+		t.Errorf("expected override rule name, got %q", decision.RuleName)
+	}
+
+	// A command that doesn't match the override's prefix still falls
+	// through to the global rule.
+	decision = engine.Evaluate(bashMsg("rm -rf /"), sess)
+	if decision.Outcome != OutcomeAsk {
+		t.Errorf("expected global rule to apply, got %v", decision.Outcome)
+	}
+}
+
+func TestEngineSetRuleEnabled(t *testing.T) {
+	engine, err := NewEngine([]Rule{
+		{Name: "bash-require", Match: Match{Tool: "Bash"}, Action: ActionRequire},
+	})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+
+	if err := engine.SetRuleEnabled("bash-require", false); err != nil {
+		t.Fatalf("SetRuleEnabled: %v", err)
+	}
+
+	decision := engine.Evaluate(bashMsg("ls"), nil)
+	if decision.Outcome != OutcomeAllow {
+		t.Errorf("expected disabled rule to be skipped, got %v", decision.Outcome)
+	}
+
+	if err := engine.SetRuleEnabled("does-not-exist", true); err == nil {
+		t.Error("expected error for unknown rule name")
+	}
+}
+
+func TestEngineReplaceRules(t *testing.T) {
+	engine, err := NewEngine([]Rule{
+		{Name: "bash-require", Match: Match{Tool: "Bash"}, Action: ActionRequire},
+	})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+
+	if err := engine.ReplaceRules([]Rule{
+		{Name: "bash-allow", Match: Match{Tool: "Bash"}, Action: ActionAutoAllow},
+	}); err != nil {
+		t.Fatalf("ReplaceRules: %v", err)
+	}
+
+	if d := engine.Evaluate(bashMsg("ls"), nil); d.Outcome != OutcomeAllow {
+		t.Errorf("expected replaced rule set to allow, got %v", d.Outcome)
+	}
+
+	if err := engine.ReplaceRules([]Rule{
+		{Name: "bad", Match: Match{CommandRegex: "("}, Action: ActionRequire},
+	}); err == nil {
+		t.Error("expected error for invalid command_regex")
+	}
+
+	// The invalid replacement must not have taken effect.
+	if d := engine.Evaluate(bashMsg("ls"), nil); d.Outcome != OutcomeAllow {
+		t.Errorf("expected prior rule set to survive a failed ReplaceRules, got %v", d.Outcome)
+	}
+}
+
+func TestEngineInvalidRegex(t *testing.T) {
+	if _, err := NewEngine([]Rule{
+		{Name: "bad", Match: Match{CommandRegex: "("}, Action: ActionRequire},
+	}); err == nil {
+		t.Error("expected error for invalid command_regex")
+	}
+}
+
+func TestExtractReasonAndCommand(t *testing.T) {
+	input, _ := json.Marshal(map[string]string{"command": "git push origin main"})
+	msg := &claude.Message{Type: claude.MessageTypeToolUse, ToolName: "Bash", ToolInput: input}
+
+	if got := extractReason(msg); got != "Git push operation" {
+		t.Errorf("extractReason = %q, want %q", got, "Git push operation")
+	}
+	if got := extractCommand(msg); got != "git push origin main" {
+		t.Errorf("extractCommand = %q, want %q", got, "git push origin main")
+	}
+}
@@ -0,0 +1,111 @@
+package approval
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/user/teleclaude/internal/session"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// EtcdStore persists pending approval requests under prefix/<id> in etcd,
+// the same layout session.EtcdStore uses for sessions, giving a multi-
+// replica bot deployment a shared view of pending approvals without
+// relying on a shared filesystem.
+type EtcdStore struct {
+	client *clientv3.Client
+	prefix string
+}
+
+// NewEtcdStore dials the given etcd endpoints and returns a store that keys
+// entries under prefix (e.g. "teleclaude/approvals").
+func NewEtcdStore(endpoints []string, prefix string, tls *session.TLSConfig) (*EtcdStore, error) {
+	cfg := clientv3.Config{
+		Endpoints:   endpoints,
+		DialTimeout: 5 * time.Second,
+	}
+
+	if tls != nil && tls.Enabled {
+		tlsConfig, err := session.BuildTLSConfig(tls)
+		if err != nil {
+			return nil, fmt.Errorf("etcd: failed to build TLS config: %w", err)
+		}
+		cfg.TLS = tlsConfig
+	}
+
+	client, err := clientv3.New(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("etcd: failed to create client: %w", err)
+	}
+
+	return &EtcdStore{client: client, prefix: strings.Trim(prefix, "/")}, nil
+}
+
+var _ RequestStore = (*EtcdStore)(nil)
+
+func (e *EtcdStore) key(id string) string {
+	return e.prefix + "/" + id
+}
+
+func (e *EtcdStore) Save(req *StoredRequest) error {
+	data, err := yaml.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err = e.client.Put(ctx, e.key(req.ID), string(data))
+	return err
+}
+
+func (e *EtcdStore) Load(id string) (*StoredRequest, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := e.client.Get(ctx, e.key(id))
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, fmt.Errorf("etcd: approval request %s not found", id)
+	}
+
+	req := &StoredRequest{}
+	if err := yaml.Unmarshal(resp.Kvs[0].Value, req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func (e *EtcdStore) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := e.client.Delete(ctx, e.key(id))
+	return err
+}
+
+func (e *EtcdStore) ListPending() ([]*StoredRequest, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := e.client.Get(ctx, e.prefix+"/", clientv3.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []*StoredRequest
+	for _, kv := range resp.Kvs {
+		req := &StoredRequest{}
+		if err := yaml.Unmarshal(kv.Value, req); err != nil {
+			continue
+		}
+		pending = append(pending, req)
+	}
+	return pending, nil
+}
@@ -0,0 +1,90 @@
+package approval
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileStore is the default RequestStore: one YAML file per pending request
+// under baseDir/approvals, the same one-file-per-record layout
+// session.Storage uses for sessions.
+type FileStore struct {
+	baseDir string
+}
+
+func NewFileStore(baseDir string) *FileStore {
+	return &FileStore{baseDir: baseDir}
+}
+
+var _ RequestStore = (*FileStore)(nil)
+
+func (s *FileStore) dir() string {
+	return filepath.Join(s.baseDir, "approvals")
+}
+
+func (s *FileStore) path(id string) string {
+	return filepath.Join(s.dir(), id+".yaml")
+}
+
+func (s *FileStore) Save(req *StoredRequest) error {
+	if err := os.MkdirAll(s.dir(), 0755); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path(req.ID), data, 0644)
+}
+
+func (s *FileStore) Load(id string) (*StoredRequest, error) {
+	data, err := os.ReadFile(s.path(id))
+	if err != nil {
+		return nil, err
+	}
+
+	req := &StoredRequest{}
+	if err := yaml.Unmarshal(data, req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func (s *FileStore) Delete(id string) error {
+	err := os.Remove(s.path(id))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func (s *FileStore) ListPending() ([]*StoredRequest, error) {
+	entries, err := os.ReadDir(s.dir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*StoredRequest{}, nil
+		}
+		return nil, err
+	}
+
+	var pending []*StoredRequest
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".yaml") {
+			continue
+		}
+
+		id := strings.TrimSuffix(entry.Name(), ".yaml")
+		req, err := s.Load(id)
+		if err != nil {
+			continue // Skip corrupted files, same as session.Storage.ListAll
+		}
+		pending = append(pending, req)
+	}
+
+	return pending, nil
+}
@@ -0,0 +1,197 @@
+package approval
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Policy is one entry of the approval.policies: config list. Unlike a
+// Rule's CommandRegex, which matches a regex against the raw command
+// string, a Policy is matched against a parsed SimpleCommand: its binary,
+// its own flags, and its own arguments. That's what lets
+// {binary: "rm", flags_any_of: ["-r","-rf","--recursive"]} catch
+// "/bin/rm -rf /tmp/x" and "rm --recursive /tmp/x" alike without also
+// matching "echo rm is scary".
+type Policy struct {
+	Binary          string   `yaml:"binary"`
+	FlagsAnyOf      []string `yaml:"flags_any_of"`
+	FlagsAllOf      []string `yaml:"flags_all_of"`
+	ArgRegex        string   `yaml:"arg_regex"`
+	RequireApproval bool     `yaml:"require_approval"`
+	Deny            bool     `yaml:"deny"`
+}
+
+// compiledPolicy is a Policy with its arg_regex compiled once, the same
+// way compiledRule pre-compiles a Rule's command_regex/cwd_glob.
+type compiledPolicy struct {
+	policy Policy
+	argRe  *regexp.Regexp
+}
+
+// compilePolicies compiles a Policy list, failing fast on an invalid
+// arg_regex rather than at evaluation time.
+func compilePolicies(policies []Policy) ([]compiledPolicy, error) {
+	compiled := make([]compiledPolicy, 0, len(policies))
+	for _, p := range policies {
+		cp := compiledPolicy{policy: p}
+		if p.ArgRegex != "" {
+			re, err := regexp.Compile(p.ArgRegex)
+			if err != nil {
+				return nil, fmt.Errorf("approval: policy %q: invalid arg_regex: %w", policyLabel(p), err)
+			}
+			cp.argRe = re
+		}
+		compiled = append(compiled, cp)
+	}
+	return compiled, nil
+}
+
+func (cp *compiledPolicy) matches(cmd SimpleCommand) bool {
+	if cp.policy.Binary != "" && cp.policy.Binary != normalizeBinary(cmd.Binary) {
+		return false
+	}
+	if len(cp.policy.FlagsAnyOf) > 0 && !containsAny(cmd.Args, cp.policy.FlagsAnyOf) {
+		return false
+	}
+	if len(cp.policy.FlagsAllOf) > 0 && !containsAll(cmd.Args, cp.policy.FlagsAllOf) {
+		return false
+	}
+	if cp.argRe != nil {
+		found := false
+		for _, a := range cmd.Args {
+			if cp.argRe.MatchString(a) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func containsAny(args, flags []string) bool {
+	for _, f := range flags {
+		for _, a := range args {
+			if a == f {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containsAll(args, flags []string) bool {
+	for _, f := range flags {
+		found := false
+		for _, a := range args {
+			if a == f {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeBinary reduces a SimpleCommand's binary to the bare name a
+// Policy's Binary field names: "/bin/rm" and "\rm" (the backslash-prefix
+// trick that skips a shell alias) both become "rm", so a Policy for "rm"
+// still catches them instead of only matching a literal bare "rm" in
+// $PATH.
+func normalizeBinary(bin string) string {
+	return path.Base(strings.TrimPrefix(bin, `\`))
+}
+
+func policyLabel(p Policy) string {
+	if p.Binary != "" {
+		return p.Binary
+	}
+	return "policy"
+}
+
+// isDangerousRedirectTarget reports whether a redirection target is one of
+// the first-class dangerous destinations: the root filesystem itself,
+// anywhere under /etc, or a /dev/sd* disk device. These are checked
+// unconditionally, without needing a Policy entry, the same way a
+// sudo-anywhere-in-the-pipeline check is.
+func isDangerousRedirectTarget(target string) bool {
+	target = strings.Trim(target, `"'`)
+	switch {
+	case target == "/":
+		return true
+	case strings.HasPrefix(target, "/etc/"):
+		return true
+	case strings.HasPrefix(target, "/dev/sd"):
+		return true
+	default:
+		return false
+	}
+}
+
+// evaluateShellPolicy parses command as a shell line and checks it
+// against the first-class redirect/sudo-pipeline checks and the
+// configured policies, in parsed (binary, flags, args) terms rather than
+// as a regex over the raw string. ok is false when nothing fired, meaning
+// command falls through to the Match/Action rule list Evaluate already
+// runs.
+func (e *Engine) evaluateShellPolicy(command string, policies []compiledPolicy) (Decision, bool) {
+	if command == "" {
+		return Decision{}, false
+	}
+
+	parsed := parseShellCommand(command)
+
+	if parsed.HasSudo() {
+		return Decision{Outcome: OutcomeAsk, RuleName: "policy:sudo-pipeline"}, true
+	}
+	for _, cmd := range parsed.Commands {
+		for _, target := range cmd.Redirects {
+			if isDangerousRedirectTarget(target) {
+				return Decision{Outcome: OutcomeAsk, RuleName: "policy:dangerous-redirect"}, true
+			}
+		}
+	}
+
+	for _, cmd := range parsed.Commands {
+		for _, cp := range policies {
+			if !cp.matches(cmd) {
+				continue
+			}
+			switch {
+			case cp.policy.Deny:
+				return Decision{Outcome: OutcomeDeny, RuleName: "policy:" + policyLabel(cp.policy)}, true
+			case cp.policy.RequireApproval:
+				return Decision{Outcome: OutcomeAsk, RuleName: "policy:" + policyLabel(cp.policy)}, true
+			default:
+				return Decision{Outcome: OutcomeAllow, RuleName: "policy:" + policyLabel(cp.policy)}, true
+			}
+		}
+	}
+
+	return Decision{}, false
+}
+
+// DefaultDangerousCommandPolicies seeds approval.policies when none are
+// configured: the structural equivalent of DefaultDangerousCommandRules'
+// keyword list, matched against each command's own binary/flags/args
+// instead of a regex over the whole raw string.
+func DefaultDangerousCommandPolicies() []Policy {
+	return []Policy{
+		{Binary: "rm", FlagsAnyOf: []string{"-r", "-rf", "-fr", "-R", "--recursive"}, RequireApproval: true},
+		{Binary: "rm", ArgRegex: `^/(etc|usr|var|boot|sys|bin|sbin)(/|$)`, RequireApproval: true},
+		{Binary: "rmdir", RequireApproval: true},
+		{Binary: "chmod", RequireApproval: true},
+		{Binary: "chown", RequireApproval: true},
+		{Binary: "dd", RequireApproval: true},
+		{Binary: "mkfs", RequireApproval: true},
+		{Binary: "fdisk", RequireApproval: true},
+		{Binary: "git", ArgRegex: `^(push|force)$`, RequireApproval: true},
+	}
+}
@@ -0,0 +1,123 @@
+package approval
+
+import "testing"
+
+func TestEngineEvaluatePolicyRequiresApprovalForRecursiveRm(t *testing.T) {
+	engine, err := NewEngine(nil)
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	if err := engine.SetPolicies([]Policy{
+		{Binary: "rm", FlagsAnyOf: []string{"-r", "-rf"}, RequireApproval: true},
+	}); err != nil {
+		t.Fatalf("SetPolicies: %v", err)
+	}
+
+	if d := engine.Evaluate(bashMsg("rm -rf /tmp/x"), nil); d.Outcome != OutcomeAsk {
+		t.Errorf("expected OutcomeAsk for rm -rf, got %v", d.Outcome)
+	}
+	if d := engine.Evaluate(bashMsg("rm /tmp/x"), nil); d.Outcome != OutcomeAllow {
+		t.Errorf("expected OutcomeAllow for a non-recursive rm, got %v", d.Outcome)
+	}
+}
+
+func TestEngineEvaluatePolicyDoesNotFalsePositiveOnSubstring(t *testing.T) {
+	engine, err := NewEngine(nil)
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	if err := engine.SetPolicies(DefaultDangerousCommandPolicies()); err != nil {
+		t.Fatalf("SetPolicies: %v", err)
+	}
+
+	if d := engine.Evaluate(bashMsg(`echo "rm is scary"`), nil); d.Outcome != OutcomeAllow {
+		t.Errorf("expected OutcomeAllow for a command merely mentioning rm, got %v", d.Outcome)
+	}
+}
+
+func TestEngineEvaluatePolicyBinaryMatchIsExact(t *testing.T) {
+	engine, err := NewEngine(nil)
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	if err := engine.SetPolicies([]Policy{
+		{Binary: "mkfs", Deny: true},
+	}); err != nil {
+		t.Fatalf("SetPolicies: %v", err)
+	}
+
+	if d := engine.Evaluate(bashMsg("mkfs.ext4 /dev/sdb1"), nil); d.Outcome != OutcomeAllow {
+		t.Errorf("expected mkfs.ext4 (a different binary than mkfs) to fall through to allow, got %v", d.Outcome)
+	}
+	if d := engine.Evaluate(bashMsg("mkfs /dev/sdb1"), nil); d.Outcome != OutcomeDeny {
+		t.Errorf("expected OutcomeDeny for mkfs, got %v", d.Outcome)
+	}
+}
+
+func TestEngineEvaluateSudoPipelineAlwaysAsks(t *testing.T) {
+	engine, err := NewEngine(nil)
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+
+	d := engine.Evaluate(bashMsg("cat /etc/passwd | sudo tee /etc/shadow"), nil)
+	if d.Outcome != OutcomeAsk {
+		t.Errorf("expected OutcomeAsk for a pipeline containing sudo, got %v", d.Outcome)
+	}
+	if d.RuleName != "policy:sudo-pipeline" {
+		t.Errorf("RuleName = %q, want policy:sudo-pipeline", d.RuleName)
+	}
+}
+
+func TestEngineEvaluateDangerousRedirectAlwaysAsks(t *testing.T) {
+	engine, err := NewEngine(nil)
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+
+	if d := engine.Evaluate(bashMsg("echo oops > /etc/passwd"), nil); d.Outcome != OutcomeAsk {
+		t.Errorf("expected OutcomeAsk for a redirect into /etc, got %v", d.Outcome)
+	}
+	if d := engine.Evaluate(bashMsg("echo fine > /tmp/scratch"), nil); d.Outcome != OutcomeAllow {
+		t.Errorf("expected OutcomeAllow for a redirect into /tmp, got %v", d.Outcome)
+	}
+}
+
+func TestEngineEvaluatePolicyArgRegexMatchesGitPush(t *testing.T) {
+	engine, err := NewEngine(nil)
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	if err := engine.SetPolicies(DefaultDangerousCommandPolicies()); err != nil {
+		t.Fatalf("SetPolicies: %v", err)
+	}
+
+	if d := engine.Evaluate(bashMsg("git push origin main"), nil); d.Outcome != OutcomeAsk {
+		t.Errorf("expected OutcomeAsk for git push, got %v", d.Outcome)
+	}
+	if d := engine.Evaluate(bashMsg("git status"), nil); d.Outcome != OutcomeAllow {
+		t.Errorf("expected OutcomeAllow for git status, got %v", d.Outcome)
+	}
+}
+
+func TestEngineSetPoliciesInvalidArgRegexLeavesPreviousPoliciesIntact(t *testing.T) {
+	engine, err := NewEngine(nil)
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	if err := engine.SetPolicies([]Policy{
+		{Binary: "rm", RequireApproval: true},
+	}); err != nil {
+		t.Fatalf("SetPolicies: %v", err)
+	}
+
+	if err := engine.SetPolicies([]Policy{
+		{Binary: "rm", ArgRegex: "("},
+	}); err == nil {
+		t.Error("expected error for invalid arg_regex")
+	}
+
+	if d := engine.Evaluate(bashMsg("rm /tmp/x"), nil); d.Outcome != OutcomeAsk {
+		t.Errorf("expected prior policy set to survive a failed SetPolicies, got %v", d.Outcome)
+	}
+}
@@ -0,0 +1,19 @@
+package approval
+
+// Role is a user's standing permission level, set per Telegram user ID in
+// config.Config.Users and carried onto the session.Session they're
+// operating so Engine.Evaluate can special-case it.
+type Role string
+
+const (
+	// RoleAdmin may bypass approval entirely (see Engine.SetAdminBypass).
+	RoleAdmin Role = "admin"
+	// RoleOperator is the normal case: global rules plus any of the
+	// user's own ApprovalOverrides apply, same as before roles existed.
+	RoleOperator Role = "operator"
+	// RoleViewer may observe sessions but never start or drive one — this
+	// is enforced in telegram.Bot's command handlers, not here, since
+	// Evaluate only ever sees tool-use messages from a session already
+	// running.
+	RoleViewer Role = "viewer"
+)
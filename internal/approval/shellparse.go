@@ -0,0 +1,211 @@
+package approval
+
+// SimpleCommand is one command segment of a parsed shell line: the binary
+// it execs, its arguments, and the target of any redirection attached to
+// it (e.g. "/etc/passwd" in "echo x > /etc/passwd").
+type SimpleCommand struct {
+	Binary    string
+	Args      []string
+	Redirects []string
+}
+
+// ParsedCommand is every SimpleCommand a shell command line would run, in
+// the order they'd start, across pipelines (|) and chains (;, &&, ||).
+type ParsedCommand struct {
+	Commands []SimpleCommand
+}
+
+// HasSudo reports whether any command in the line runs as sudo, regardless
+// of where in a pipeline or chain it appears, so "cmd | sudo tee /etc/x"
+// and "sudo cmd" are caught the same way.
+func (p ParsedCommand) HasSudo() bool {
+	for _, cmd := range p.Commands {
+		if cmd.Binary == "sudo" {
+			return true
+		}
+	}
+	return false
+}
+
+// separators split a shell command line into SimpleCommands. Pipelines and
+// chains are treated the same for policy purposes: what matters is which
+// binaries run and with what arguments, not how they're wired together.
+var separators = map[string]bool{";": true, "&&": true, "||": true, "|": true}
+
+// parseShellCommand tokenizes cmd the way a shell would and splits it into
+// SimpleCommands. It's a compact in-tree lexer, not a full POSIX shell
+// grammar: quoting, $() and backtick command substitution, pipelines,
+// chains, and redirections are understood structurally; command
+// substitution itself is kept opaque (its contents aren't recursed into).
+// This is enough to tell "rm -rf /tmp/x" from "echo rm is scary" and from
+// "rm -rf /etc", which a command_regex over the raw string can't.
+func parseShellCommand(cmd string) ParsedCommand {
+	words := mergeFdRedirects(tokenizeShellWords(cmd))
+
+	var parsed ParsedCommand
+	cur := SimpleCommand{}
+	flush := func() {
+		if cur.Binary != "" || len(cur.Args) > 0 || len(cur.Redirects) > 0 {
+			parsed.Commands = append(parsed.Commands, cur)
+		}
+		cur = SimpleCommand{}
+	}
+
+	for i := 0; i < len(words); i++ {
+		w := words[i]
+		if separators[w] {
+			flush()
+			continue
+		}
+		if isRedirectOp(w) {
+			if i+1 < len(words) {
+				cur.Redirects = append(cur.Redirects, words[i+1])
+				i++
+			}
+			continue
+		}
+		if cur.Binary == "" {
+			cur.Binary = w
+		} else {
+			cur.Args = append(cur.Args, w)
+		}
+	}
+	flush()
+
+	return parsed
+}
+
+var redirectOps = map[string]bool{">": true, ">>": true, "&>": true, "2>": true, "2>>": true, "<": true}
+
+func isRedirectOp(w string) bool {
+	return redirectOps[w]
+}
+
+// mergeFdRedirects folds the "2" + ">"/">>" pair tokenizeShellWords emits
+// for a file-descriptor redirect (e.g. "2> /dev/null") into a single "2>"
+// or "2>>" token, so parseShellCommand's redirect handling doesn't need to
+// special-case it.
+func mergeFdRedirects(words []string) []string {
+	merged := make([]string, 0, len(words))
+	for i := 0; i < len(words); i++ {
+		if words[i] == "2" && i+1 < len(words) && (words[i+1] == ">" || words[i+1] == ">>") {
+			merged = append(merged, "2"+words[i+1])
+			i++
+			continue
+		}
+		merged = append(merged, words[i])
+	}
+	return merged
+}
+
+// tokenizeShellWords splits cmd into words the way a shell would: single
+// and double quotes are consumed literally (no expansion, since policy
+// matching only needs the resulting text), $(...) and `...` command
+// substitution is captured whole as one opaque word so it isn't split on
+// internal whitespace or mistaken for an operator, and the operators a
+// Bash tool call actually uses (;, &&, ||, |, >, >>, <, &>) are emitted as
+// their own words.
+func tokenizeShellWords(cmd string) []string {
+	var words []string
+	var b []rune
+	inWord := false
+
+	flush := func() {
+		if inWord {
+			words = append(words, string(b))
+			b = b[:0]
+			inWord = false
+		}
+	}
+
+	runes := []rune(cmd)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case c == '\'':
+			inWord = true
+			i++
+			for i < len(runes) && runes[i] != '\'' {
+				b = append(b, runes[i])
+				i++
+			}
+		case c == '"':
+			inWord = true
+			i++
+			for i < len(runes) && runes[i] != '"' {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				b = append(b, runes[i])
+				i++
+			}
+		case c == '`':
+			inWord = true
+			b = append(b, c)
+			i++
+			for i < len(runes) && runes[i] != '`' {
+				b = append(b, runes[i])
+				i++
+			}
+			if i < len(runes) {
+				b = append(b, runes[i])
+			}
+		case c == '$' && i+1 < len(runes) && runes[i+1] == '(':
+			inWord = true
+			depth := 0
+			for i < len(runes) {
+				b = append(b, runes[i])
+				if runes[i] == '(' {
+					depth++
+				} else if runes[i] == ')' {
+					depth--
+					if depth == 0 {
+						break
+					}
+				}
+				i++
+			}
+		case c == ' ' || c == '\t' || c == '\n':
+			flush()
+		case c == ';':
+			flush()
+			words = append(words, ";")
+		case c == '|':
+			flush()
+			if i+1 < len(runes) && runes[i+1] == '|' {
+				words = append(words, "||")
+				i++
+			} else {
+				words = append(words, "|")
+			}
+		case c == '&':
+			flush()
+			if i+1 < len(runes) && runes[i+1] == '&' {
+				words = append(words, "&&")
+				i++
+			} else if i+1 < len(runes) && runes[i+1] == '>' {
+				words = append(words, "&>")
+				i++
+			} else {
+				words = append(words, "&")
+			}
+		case c == '>':
+			flush()
+			if i+1 < len(runes) && runes[i+1] == '>' {
+				words = append(words, ">>")
+				i++
+			} else {
+				words = append(words, ">")
+			}
+		case c == '<':
+			flush()
+			words = append(words, "<")
+		default:
+			inWord = true
+			b = append(b, c)
+		}
+	}
+	flush()
+
+	return words
+}
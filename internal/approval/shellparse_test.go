@@ -0,0 +1,77 @@
+package approval
+
+import "testing"
+
+func TestParseShellCommandSimple(t *testing.T) {
+	parsed := parseShellCommand("rm -rf /tmp/x")
+	if len(parsed.Commands) != 1 {
+		t.Fatalf("Commands = %d, want 1", len(parsed.Commands))
+	}
+	cmd := parsed.Commands[0]
+	if cmd.Binary != "rm" {
+		t.Errorf("Binary = %q, want rm", cmd.Binary)
+	}
+	if len(cmd.Args) != 2 || cmd.Args[0] != "-rf" || cmd.Args[1] != "/tmp/x" {
+		t.Errorf("Args = %v, want [-rf /tmp/x]", cmd.Args)
+	}
+}
+
+func TestParseShellCommandIgnoresSubstringInQuotes(t *testing.T) {
+	parsed := parseShellCommand(`echo "rm is scary"`)
+	if len(parsed.Commands) != 1 {
+		t.Fatalf("Commands = %d, want 1", len(parsed.Commands))
+	}
+	cmd := parsed.Commands[0]
+	if cmd.Binary != "echo" {
+		t.Errorf("Binary = %q, want echo", cmd.Binary)
+	}
+	if len(cmd.Args) != 1 || cmd.Args[0] != "rm is scary" {
+		t.Errorf("Args = %v, want [rm is scary]", cmd.Args)
+	}
+}
+
+func TestParseShellCommandPipelineAndChain(t *testing.T) {
+	parsed := parseShellCommand("cat /etc/passwd | sudo tee /etc/shadow; echo done")
+	if len(parsed.Commands) != 3 {
+		t.Fatalf("Commands = %d, want 3", len(parsed.Commands))
+	}
+	if parsed.Commands[0].Binary != "cat" || parsed.Commands[1].Binary != "sudo" || parsed.Commands[2].Binary != "echo" {
+		t.Errorf("unexpected binaries: %v", parsed.Commands)
+	}
+	if !parsed.HasSudo() {
+		t.Error("HasSudo() = false, want true")
+	}
+}
+
+func TestParseShellCommandRedirect(t *testing.T) {
+	parsed := parseShellCommand("echo oops > /etc/passwd")
+	if len(parsed.Commands) != 1 {
+		t.Fatalf("Commands = %d, want 1", len(parsed.Commands))
+	}
+	redirects := parsed.Commands[0].Redirects
+	if len(redirects) != 1 || redirects[0] != "/etc/passwd" {
+		t.Errorf("Redirects = %v, want [/etc/passwd]", redirects)
+	}
+}
+
+func TestParseShellCommandFdRedirect(t *testing.T) {
+	parsed := parseShellCommand("rm -rf /tmp/x 2> /dev/null")
+	if len(parsed.Commands) != 1 {
+		t.Fatalf("Commands = %d, want 1", len(parsed.Commands))
+	}
+	redirects := parsed.Commands[0].Redirects
+	if len(redirects) != 1 || redirects[0] != "/dev/null" {
+		t.Errorf("Redirects = %v, want [/dev/null]", redirects)
+	}
+}
+
+func TestParseShellCommandCommandSubstitutionIsOpaque(t *testing.T) {
+	parsed := parseShellCommand("echo $(whoami) done")
+	if len(parsed.Commands) != 1 {
+		t.Fatalf("Commands = %d, want 1", len(parsed.Commands))
+	}
+	cmd := parsed.Commands[0]
+	if len(cmd.Args) != 2 || cmd.Args[0] != "$(whoami)" || cmd.Args[1] != "done" {
+		t.Errorf("Args = %v, want [$(whoami) done]", cmd.Args)
+	}
+}
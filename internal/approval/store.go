@@ -0,0 +1,42 @@
+package approval
+
+import "time"
+
+// StoredRequest is the persisted form of a Request: everything except its
+// in-memory decision channel, which is recreated by LoadPending on replay.
+type StoredRequest struct {
+	ID        string
+	SessionID string
+	ToolName  string
+	Reason    string
+	Command   string
+	CreatedAt time.Time
+}
+
+// RequestStore persists pending approval requests, so a bot restart can
+// replay them instead of silently losing every approval a user hadn't yet
+// answered. The in-memory Workflow is the default (no store attached);
+// attaching one is optional, unlike session.Manager, which always reads
+// and writes through its pluggable session.SessionStore.
+//
+// FileStore, ConsulStore, and EtcdStore mirror session's own
+// file/consul/etcd backends (same key layout, same YAML encoding), so a
+// multi-replica deployment can point approval.Persist at the same Consul
+// or etcd cluster it already uses for session.SessionStore instead of
+// each replica only knowing about the approvals it created locally.
+//
+// Not covered by this interface: a Watch-based change stream (nothing
+// currently needs to react to another replica creating or resolving a
+// request outside of the startup replay LoadPending already does) and
+// session.Manager's own persistence of ProjectPath/ClaudeSessionID/
+// TotalCostUSD/LastActive, which is a separate, session-scoped concern.
+type RequestStore interface {
+	Save(req *StoredRequest) error
+	Load(id string) (*StoredRequest, error)
+	Delete(id string) error
+
+	// ListPending returns every request that hasn't been deleted yet, i.e.
+	// every approval still awaiting a decision when the store was last
+	// written to.
+	ListPending() ([]*StoredRequest, error)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"log"
 	"sync"
 	"time"
 )
@@ -18,9 +19,22 @@ type Request struct {
 	decision  chan bool
 }
 
+// toStored strips the unserializable decision channel for RequestStore.Save.
+func (r *Request) toStored() *StoredRequest {
+	return &StoredRequest{
+		ID:        r.ID,
+		SessionID: r.SessionID,
+		ToolName:  r.ToolName,
+		Reason:    r.Reason,
+		Command:   r.Command,
+		CreatedAt: r.CreatedAt,
+	}
+}
+
 type Workflow struct {
 	timeout  time.Duration
 	requests map[string]*Request
+	store    RequestStore
 	mu       sync.RWMutex
 }
 
@@ -31,6 +45,15 @@ func NewWorkflow(timeout time.Duration) *Workflow {
 	}
 }
 
+// SetStore attaches a RequestStore so pending requests survive a bot
+// restart; call LoadPending once at startup to replay them. Leaving this
+// unset (the default) keeps Workflow purely in-memory, same as before.
+func (w *Workflow) SetStore(store RequestStore) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.store = store
+}
+
 func (w *Workflow) CreateRequest(sessionID, toolName, reason, command string) string {
 	id := generateRequestID()
 
@@ -46,8 +69,15 @@ func (w *Workflow) CreateRequest(sessionID, toolName, reason, command string) st
 
 	w.mu.Lock()
 	w.requests[id] = req
+	store := w.store
 	w.mu.Unlock()
 
+	if store != nil {
+		if err := store.Save(req.toStored()); err != nil {
+			log.Printf("approval: failed to persist request %s: %v", id, err)
+		}
+	}
+
 	// Auto-cleanup after timeout
 	go func() {
 		time.Sleep(w.timeout + time.Second)
@@ -57,6 +87,53 @@ func (w *Workflow) CreateRequest(sessionID, toolName, reason, command string) st
 	return id
 }
 
+// LoadPending replays requests a RequestStore still has pending from
+// before a restart: each gets a fresh decision channel and auto-cleanup
+// timer (scaled down by how much of its timeout already elapsed), so
+// callers that were awaiting WaitForDecision would have. The caller is
+// responsible for re-presenting each returned Request to its user — the
+// store only remembers what was asked, not where it was asked.
+func (w *Workflow) LoadPending(store RequestStore) ([]*Request, error) {
+	stored, err := store.ListPending()
+	if err != nil {
+		return nil, err
+	}
+
+	w.mu.Lock()
+	w.store = store
+	w.mu.Unlock()
+
+	requests := make([]*Request, 0, len(stored))
+	for _, sr := range stored {
+		req := &Request{
+			ID:        sr.ID,
+			SessionID: sr.SessionID,
+			ToolName:  sr.ToolName,
+			Reason:    sr.Reason,
+			Command:   sr.Command,
+			CreatedAt: sr.CreatedAt,
+			decision:  make(chan bool, 1),
+		}
+
+		w.mu.Lock()
+		w.requests[req.ID] = req
+		w.mu.Unlock()
+
+		remaining := w.timeout - time.Since(req.CreatedAt)
+		if remaining < 0 {
+			remaining = 0
+		}
+		go func(id string) {
+			time.Sleep(remaining + time.Second)
+			w.cleanup(id)
+		}(req.ID)
+
+		requests = append(requests, req)
+	}
+
+	return requests, nil
+}
+
 func (w *Workflow) GetRequest(id string) *Request {
 	w.mu.RLock()
 	defer w.mu.RUnlock()
@@ -114,7 +191,14 @@ func (w *Workflow) WaitForDecision(ctx context.Context, id string) (bool, error)
 func (w *Workflow) cleanup(id string) {
 	w.mu.Lock()
 	delete(w.requests, id)
+	store := w.store
 	w.mu.Unlock()
+
+	if store != nil {
+		if err := store.Delete(id); err != nil {
+			log.Printf("approval: failed to delete persisted request %s: %v", id, err)
+		}
+	}
 }
 
 func (w *Workflow) GetPendingForSession(sessionID string) []*Request {
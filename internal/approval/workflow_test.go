@@ -72,6 +72,51 @@ func TestWorkflowTimeout(t *testing.T) {
 	}
 }
 
+func TestWorkflowPersistsAndReplaysPending(t *testing.T) {
+	store := NewFileStore(t.TempDir())
+
+	wf := NewWorkflow(5 * time.Second)
+	wf.SetStore(store)
+	reqID := wf.CreateRequest("sess123", "Bash", "Run tests", "go test ./...")
+
+	// Simulate a restart: a fresh Workflow with no in-memory state, replaying
+	// from the same store.
+	restarted := NewWorkflow(5 * time.Second)
+	replayed, err := restarted.LoadPending(store)
+	if err != nil {
+		t.Fatalf("LoadPending() error = %v", err)
+	}
+	if len(replayed) != 1 {
+		t.Fatalf("LoadPending() returned %d requests, want 1", len(replayed))
+	}
+	if replayed[0].ID != reqID {
+		t.Errorf("replayed ID = %s, want %s", replayed[0].ID, reqID)
+	}
+	if replayed[0].Command != "go test ./..." {
+		t.Errorf("replayed Command = %s, want 'go test ./...'", replayed[0].Command)
+	}
+
+	// The replayed request is fully live: it can still be approved.
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		restarted.Approve(reqID)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	approved, err := restarted.WaitForDecision(ctx, reqID)
+	if err != nil {
+		t.Fatalf("WaitForDecision() error = %v", err)
+	}
+	if !approved {
+		t.Error("WaitForDecision() = false, want true")
+	}
+
+	if _, err := store.Load(reqID); err == nil {
+		t.Error("expected request to be deleted from store after decision")
+	}
+}
+
 func TestWorkflowGetPendingRequest(t *testing.T) {
 	wf := NewWorkflow(5 * time.Second)
 
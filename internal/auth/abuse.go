@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// AbuseSignal identifies what kind of misbehavior AbuseTracker is counting.
+// Signals are mixed together per user: repeatedly denied approvals and
+// repeated failed messages both count toward the same threshold, since
+// either one is a sign a user is probing or malfunctioning.
+type AbuseSignal string
+
+const (
+	SignalDeniedApproval AbuseSignal = "denied_approval"
+	SignalFailedMessage  AbuseSignal = "failed_message"
+)
+
+// AbuseTracker counts abuse signals per user within a rolling window and,
+// once threshold signals land within it, issues a short automatic ban
+// through BanList rather than waiting for an admin to notice and run
+// /ban manually.
+type AbuseTracker struct {
+	bans      *BanList
+	threshold int
+	window    time.Duration
+	banTTL    time.Duration
+
+	mu     sync.Mutex
+	events map[int64][]time.Time
+}
+
+// NewAbuseTracker returns a tracker that auto-bans a user for banTTL once
+// they've produced threshold signals within window. A non-positive
+// threshold disables auto-banning (Record becomes a no-op).
+func NewAbuseTracker(bans *BanList, threshold int, window, banTTL time.Duration) *AbuseTracker {
+	return &AbuseTracker{
+		bans:      bans,
+		threshold: threshold,
+		window:    window,
+		banTTL:    banTTL,
+		events:    make(map[int64][]time.Time),
+	}
+}
+
+// Record logs one abuse signal for userID, auto-banning them if this pushes
+// their count within window up to threshold.
+func (a *AbuseTracker) Record(userID int64, signal AbuseSignal) {
+	if a.threshold <= 0 {
+		return
+	}
+
+	a.mu.Lock()
+	now := time.Now()
+	cutoff := now.Add(-a.window)
+
+	kept := a.events[userID][:0]
+	for _, t := range a.events[userID] {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	kept = append(kept, now)
+
+	tripped := len(kept) >= a.threshold
+	if tripped {
+		delete(a.events, userID)
+	} else {
+		a.events[userID] = kept
+	}
+	a.mu.Unlock()
+
+	if tripped && a.bans != nil {
+		reason := fmt.Sprintf("automatic: %d %s signal(s) within %s", a.threshold, signal, a.window)
+		a.bans.Ban(DimensionUser, fmt.Sprintf("%d", userID), reason, 0, a.banTTL)
+	}
+}
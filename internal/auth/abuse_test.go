@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAbuseTrackerAutoBansAtThreshold(t *testing.T) {
+	bans, err := NewBanList(filepath.Join(t.TempDir(), "bans.yaml"))
+	if err != nil {
+		t.Fatalf("NewBanList() error = %v", err)
+	}
+
+	tracker := NewAbuseTracker(bans, 3, time.Minute, 10*time.Minute)
+
+	tracker.Record(111, SignalDeniedApproval)
+	tracker.Record(111, SignalDeniedApproval)
+	if banned, _ := bans.IsUserBanned(111, ""); banned {
+		t.Fatal("user banned before reaching threshold")
+	}
+
+	tracker.Record(111, SignalFailedMessage)
+	banned, entry := bans.IsUserBanned(111, "")
+	if !banned {
+		t.Fatal("expected auto-ban after threshold signals")
+	}
+	if entry.Remaining() <= 0 {
+		t.Error("expected a time-limited ban, got permanent or expired")
+	}
+}
+
+func TestAbuseTrackerWindowResetsCount(t *testing.T) {
+	bans, err := NewBanList(filepath.Join(t.TempDir(), "bans.yaml"))
+	if err != nil {
+		t.Fatalf("NewBanList() error = %v", err)
+	}
+
+	tracker := NewAbuseTracker(bans, 2, 20*time.Millisecond, time.Minute)
+
+	tracker.Record(222, SignalFailedMessage)
+	time.Sleep(30 * time.Millisecond)
+	tracker.Record(222, SignalFailedMessage)
+
+	if banned, _ := bans.IsUserBanned(222, ""); banned {
+		t.Error("expected no ban: signals fell outside the same window")
+	}
+}
+
+func TestAbuseTrackerDisabledWhenNonPositive(t *testing.T) {
+	bans, err := NewBanList(filepath.Join(t.TempDir(), "bans.yaml"))
+	if err != nil {
+		t.Fatalf("NewBanList() error = %v", err)
+	}
+
+	tracker := NewAbuseTracker(bans, 0, time.Minute, time.Minute)
+	for i := 0; i < 10; i++ {
+		tracker.Record(333, SignalFailedMessage)
+	}
+
+	if banned, _ := bans.IsUserBanned(333, ""); banned {
+		t.Error("expected no auto-ban with threshold disabled")
+	}
+}
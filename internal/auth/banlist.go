@@ -0,0 +1,238 @@
+package auth
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Dimension identifies what kind of identifier a BanEntry matches against.
+type Dimension string
+
+const (
+	DimensionUser     Dimension = "user"     // Telegram user ID
+	DimensionUsername Dimension = "username" // Telegram @username, glob-matched
+	DimensionChat     Dimension = "chat"     // Telegram chat ID
+)
+
+// BanEntry is a single ban, optionally time-limited.
+type BanEntry struct {
+	Dimension Dimension `yaml:"dimension"`
+	Target    string    `yaml:"target"` // user ID, username glob, or chat ID as a string
+	Reason    string    `yaml:"reason"`
+	Issuer    int64     `yaml:"issuer"`
+	CreatedAt time.Time `yaml:"created_at"`
+	ExpiresAt time.Time `yaml:"expires_at,omitempty"` // zero value means permanent
+}
+
+func (e *BanEntry) expired(now time.Time) bool {
+	return !e.ExpiresAt.IsZero() && now.After(e.ExpiresAt)
+}
+
+// Remaining returns how long the ban has left, or 0 for a permanent ban.
+func (e *BanEntry) Remaining() time.Duration {
+	if e.ExpiresAt.IsZero() {
+		return 0
+	}
+	return time.Until(e.ExpiresAt)
+}
+
+func (e *BanEntry) key() string {
+	return string(e.Dimension) + ":" + e.Target
+}
+
+// BanList tracks user, username, and chat bans with optional TTLs, backing
+// them with a YAML file so they survive a bot restart. Expired entries are
+// ignored on lookup and dropped by a background sweep so the list doesn't
+// grow unbounded.
+type BanList struct {
+	path    string
+	mu      sync.RWMutex
+	entries map[string]*BanEntry
+}
+
+// NewBanList loads any existing bans from path (created on first Ban call if
+// it doesn't exist yet) and starts a background sweep to drop expired
+// entries.
+func NewBanList(path string) (*BanList, error) {
+	b := &BanList{
+		path:    path,
+		entries: make(map[string]*BanEntry),
+	}
+
+	if err := b.load(); err != nil {
+		return nil, err
+	}
+
+	go b.sweepLoop()
+	return b, nil
+}
+
+func (b *BanList) load() error {
+	data, err := os.ReadFile(b.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	var entries []*BanEntry
+	if err := yaml.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, e := range entries {
+		b.entries[e.key()] = e
+	}
+	return nil
+}
+
+func (b *BanList) save() error {
+	b.mu.RLock()
+	entries := make([]*BanEntry, 0, len(b.entries))
+	for _, e := range b.entries {
+		entries = append(entries, e)
+	}
+	b.mu.RUnlock()
+
+	data, err := yaml.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(b.path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(b.path, data, 0644)
+}
+
+// Ban adds or replaces a ban entry. A zero ttl means permanent.
+func (b *BanList) Ban(dimension Dimension, target, reason string, issuer int64, ttl time.Duration) error {
+	entry := &BanEntry{
+		Dimension: dimension,
+		Target:    target,
+		Reason:    reason,
+		Issuer:    issuer,
+		CreatedAt: time.Now(),
+	}
+	if ttl > 0 {
+		entry.ExpiresAt = time.Now().Add(ttl)
+	}
+
+	b.mu.Lock()
+	b.entries[entry.key()] = entry
+	b.mu.Unlock()
+
+	return b.save()
+}
+
+// Unban removes a ban entry, if any.
+func (b *BanList) Unban(dimension Dimension, target string) error {
+	key := string(dimension) + ":" + target
+
+	b.mu.Lock()
+	delete(b.entries, key)
+	b.mu.Unlock()
+
+	return b.save()
+}
+
+func (b *BanList) lookup(dimension Dimension, target string) *BanEntry {
+	key := string(dimension) + ":" + target
+
+	b.mu.RLock()
+	entry, ok := b.entries[key]
+	b.mu.RUnlock()
+
+	if !ok || entry.expired(time.Now()) {
+		return nil
+	}
+	return entry
+}
+
+// IsUserBanned reports whether userID or username (matched as a glob, e.g.
+// "spam_*") is currently banned.
+func (b *BanList) IsUserBanned(userID int64, username string) (bool, *BanEntry) {
+	if entry := b.lookup(DimensionUser, fmt.Sprintf("%d", userID)); entry != nil {
+		return true, entry
+	}
+
+	if username != "" {
+		if entry := b.matchUsername(username); entry != nil {
+			return true, entry
+		}
+	}
+
+	return false, nil
+}
+
+func (b *BanList) matchUsername(username string) *BanEntry {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	now := time.Now()
+	for _, entry := range b.entries {
+		if entry.Dimension != DimensionUsername || entry.expired(now) {
+			continue
+		}
+		if ok, _ := path.Match(entry.Target, username); ok {
+			return entry
+		}
+	}
+	return nil
+}
+
+// IsChatBanned reports whether chatID is currently banned.
+func (b *BanList) IsChatBanned(chatID int64) (bool, *BanEntry) {
+	entry := b.lookup(DimensionChat, fmt.Sprintf("%d", chatID))
+	return entry != nil, entry
+}
+
+// List returns all active (non-expired) bans.
+func (b *BanList) List() []*BanEntry {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	now := time.Now()
+	var active []*BanEntry
+	for _, entry := range b.entries {
+		if !entry.expired(now) {
+			active = append(active, entry)
+		}
+	}
+	return active
+}
+
+func (b *BanList) sweepLoop() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		b.sweep()
+	}
+}
+
+func (b *BanList) sweep() {
+	b.mu.Lock()
+	now := time.Now()
+	changed := false
+	for key, entry := range b.entries {
+		if entry.expired(now) {
+			delete(b.entries, key)
+			changed = true
+		}
+	}
+	b.mu.Unlock()
+
+	if changed {
+		b.save()
+	}
+}
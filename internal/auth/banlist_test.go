@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBanListUserBan(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bans.yaml")
+	bans, err := NewBanList(path)
+	if err != nil {
+		t.Fatalf("NewBanList() error = %v", err)
+	}
+
+	if banned, _ := bans.IsUserBanned(111, "alice"); banned {
+		t.Error("IsUserBanned(111) = true before any ban")
+	}
+
+	if err := bans.Ban(DimensionUser, "111", "spam", 999, 0); err != nil {
+		t.Fatalf("Ban() error = %v", err)
+	}
+
+	banned, entry := bans.IsUserBanned(111, "alice")
+	if !banned {
+		t.Fatal("IsUserBanned(111) = false after Ban()")
+	}
+	if entry.Reason != "spam" {
+		t.Errorf("Reason = %s, want spam", entry.Reason)
+	}
+
+	if err := bans.Unban(DimensionUser, "111"); err != nil {
+		t.Fatalf("Unban() error = %v", err)
+	}
+	if banned, _ := bans.IsUserBanned(111, "alice"); banned {
+		t.Error("IsUserBanned(111) = true after Unban()")
+	}
+}
+
+func TestBanListExpiry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bans.yaml")
+	bans, err := NewBanList(path)
+	if err != nil {
+		t.Fatalf("NewBanList() error = %v", err)
+	}
+
+	if err := bans.Ban(DimensionUser, "222", "temp", 1, time.Millisecond); err != nil {
+		t.Fatalf("Ban() error = %v", err)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	if banned, _ := bans.IsUserBanned(222, ""); banned {
+		t.Error("IsUserBanned(222) = true after TTL expired")
+	}
+}
+
+func TestBanListUsernameGlob(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bans.yaml")
+	bans, err := NewBanList(path)
+	if err != nil {
+		t.Fatalf("NewBanList() error = %v", err)
+	}
+
+	if err := bans.Ban(DimensionUsername, "spam_*", "bot account", 1, 0); err != nil {
+		t.Fatalf("Ban() error = %v", err)
+	}
+
+	if banned, _ := bans.IsUserBanned(333, "spam_bot42"); !banned {
+		t.Error("IsUserBanned should match username glob spam_*")
+	}
+	if banned, _ := bans.IsUserBanned(444, "real_user"); banned {
+		t.Error("IsUserBanned should not match real_user against spam_*")
+	}
+}
+
+func TestBanListPersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bans.yaml")
+
+	bans, err := NewBanList(path)
+	if err != nil {
+		t.Fatalf("NewBanList() error = %v", err)
+	}
+	if err := bans.Ban(DimensionChat, "555", "abuse", 1, 0); err != nil {
+		t.Fatalf("Ban() error = %v", err)
+	}
+
+	reloaded, err := NewBanList(path)
+	if err != nil {
+		t.Fatalf("NewBanList() reload error = %v", err)
+	}
+	if banned, _ := reloaded.IsChatBanned(555); !banned {
+		t.Error("ban should survive reload from disk")
+	}
+}
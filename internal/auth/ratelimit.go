@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimiter enforces a sliding-window cap on how many actions a single
+// user may perform in a given duration, e.g. how many prompts they can send
+// per minute before being asked to slow down.
+type RateLimiter struct {
+	limit  int
+	window time.Duration
+
+	mu      sync.Mutex
+	history map[int64][]time.Time
+}
+
+// NewRateLimiter returns a limiter allowing limit actions per window. A
+// non-positive limit disables the check entirely (Allow always true).
+func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{
+		limit:   limit,
+		window:  window,
+		history: make(map[int64][]time.Time),
+	}
+}
+
+// Allow reports whether userID may perform another action right now,
+// recording this attempt if so.
+func (r *RateLimiter) Allow(userID int64) bool {
+	if r.limit <= 0 {
+		return true
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	cutoff := now.Add(-r.window)
+
+	kept := r.history[userID][:0]
+	for _, t := range r.history[userID] {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+
+	if len(kept) >= r.limit {
+		r.history[userID] = kept
+		return false
+	}
+
+	r.history[userID] = append(kept, now)
+	return true
+}
@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimiterAllowsUpToLimit(t *testing.T) {
+	rl := NewRateLimiter(2, time.Minute)
+
+	if !rl.Allow(1) {
+		t.Error("Allow() #1 = false, want true")
+	}
+	if !rl.Allow(1) {
+		t.Error("Allow() #2 = false, want true")
+	}
+	if rl.Allow(1) {
+		t.Error("Allow() #3 = true, want false (over limit)")
+	}
+}
+
+func TestRateLimiterPerUser(t *testing.T) {
+	rl := NewRateLimiter(1, time.Minute)
+
+	if !rl.Allow(1) {
+		t.Error("Allow(1) = false, want true")
+	}
+	if !rl.Allow(2) {
+		t.Error("Allow(2) = false, want true — separate user, separate budget")
+	}
+}
+
+func TestRateLimiterWindowExpiry(t *testing.T) {
+	rl := NewRateLimiter(1, 20*time.Millisecond)
+
+	if !rl.Allow(1) {
+		t.Error("Allow() #1 = false, want true")
+	}
+	if rl.Allow(1) {
+		t.Error("Allow() #2 = true, want false (still in window)")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	if !rl.Allow(1) {
+		t.Error("Allow() after window expiry = false, want true")
+	}
+}
+
+func TestRateLimiterDisabledWhenNonPositive(t *testing.T) {
+	rl := NewRateLimiter(0, time.Minute)
+
+	for i := 0; i < 5; i++ {
+		if !rl.Allow(1) {
+			t.Error("Allow() = false with limit 0, want always true")
+		}
+	}
+}
@@ -4,25 +4,50 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"os"
-	"os/exec"
+	"log"
 	"strconv"
 	"sync"
 	"syscall"
+	"time"
 
-	"github.com/creack/pty"
+	"github.com/user/teleclaude/internal/runtime"
+	"github.com/user/teleclaude/internal/runtime/local"
+	"github.com/user/teleclaude/internal/session"
 )
 
+// Default PTY dimensions. The underlying claude CLI renders tables and long
+// tool output assuming a real terminal; the pty default of 80x24 truncates
+// it badly once that output reaches Formatter.
+const (
+	DefaultWinCols = 200
+	DefaultWinRows = 50
+)
+
+// DefaultStopGrace is how long Stop waits for the claude CLI to exit after
+// SIGTERM before escalating to SIGKILL.
+const DefaultStopGrace = 5 * time.Second
+
+// Controller drives one claude CLI invocation, parsing its stream-json
+// output into Messages. It's backend-agnostic: the process or container
+// actually running claude is owned by a runtime.Runtime (runtime/local by
+// default, or runtime/docker for a sandboxed session), so Controller only
+// ever deals with a generic stdin/stdout stream.
 type Controller struct {
 	workDir        string
 	sessionID      string
 	maxTurns       int
 	permissionMode string
+	winCols        uint16
+	winRows        uint16
+	stopGrace      time.Duration
+
+	rt      runtime.Runtime
+	session *session.Session
 
-	cmd    *exec.Cmd
-	ptmx   *os.File
-	Output chan *Message
-	parser *Parser
+	stream    io.Writer
+	Output    chan *Message
+	parser    *Parser
+	stopTimer *time.Timer
 
 	mu      sync.Mutex
 	running bool
@@ -34,17 +59,58 @@ func NewController(workDir string, maxTurns int, permissionMode string) *Control
 		workDir:        workDir,
 		maxTurns:       maxTurns,
 		permissionMode: permissionMode,
+		winCols:        DefaultWinCols,
+		winRows:        DefaultWinRows,
+		stopGrace:      DefaultStopGrace,
+		rt:             local.New(),
 		Output:         make(chan *Message, 100),
 		parser:         NewParser(),
 	}
 }
 
+// SetStopGrace overrides how long Stop waits between SIGTERM and SIGKILL.
+func (c *Controller) SetStopGrace(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stopGrace = d
+}
+
+// SetWinSize overrides the PTY dimensions used on the next Start. Call
+// before Start; to resize a running controller use Resize instead.
+func (c *Controller) SetWinSize(cols, rows uint16) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.winCols = cols
+	c.winRows = rows
+}
+
 func (c *Controller) SetSessionID(id string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	c.sessionID = id
 }
 
+// SetRuntime overrides the runtime.Runtime backend used on the next Start
+// — e.g. runtime/docker.New(...) to run this session inside a sandbox
+// container instead of the default host process (runtime/local). Call
+// before Start.
+func (c *Controller) SetRuntime(rt runtime.Runtime) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rt = rt
+}
+
+// SetSession attaches the teleclaude session.Session this controller is
+// driving, so the runtime backend can read its ProjectPath/ContainerID
+// (and, for runtime/docker, write a newly created container's ID back
+// onto it). Call before Start; if never called, Start falls back to a
+// throwaway session carrying only workDir.
+func (c *Controller) SetSession(sess *session.Session) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.session = sess
+}
+
 func (c *Controller) buildArgs(prompt string) []string {
 	args := []string{
 		"-p", prompt,
@@ -70,50 +136,45 @@ func (c *Controller) Start(ctx context.Context, prompt string) error {
 		return fmt.Errorf("controller already running")
 	}
 	c.running = true
+	rt := c.rt
+	sess := c.session
+	if sess == nil {
+		sess = &session.Session{ProjectPath: c.workDir}
+	}
 	c.mu.Unlock()
 
 	ctx, cancel := context.WithCancel(ctx)
 	c.cancel = cancel
 
 	args := c.buildArgs(prompt)
-	c.cmd = exec.CommandContext(ctx, "claude", args...)
-	c.cmd.Dir = c.workDir
-
-	// Use process group for clean termination
-	c.cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
 
-	var err error
-	c.ptmx, err = pty.Start(c.cmd)
+	stream, err := rt.Start(ctx, sess, args)
 	if err != nil {
 		c.mu.Lock()
 		c.running = false
 		c.mu.Unlock()
-		return fmt.Errorf("failed to start PTY: %w", err)
+		return fmt.Errorf("failed to start claude: %w", err)
 	}
 
-	// Stream output
-	go c.streamOutput()
+	c.mu.Lock()
+	c.stream = stream
+	c.mu.Unlock()
 
-	// Wait for completion
-	go func() {
-		c.cmd.Wait()
-		c.mu.Lock()
-		c.running = false
-		c.mu.Unlock()
-		if c.ptmx != nil {
-			c.ptmx.Close()
-		}
-	}()
+	if err := rt.Resize(c.winCols, c.winRows); err != nil {
+		log.Printf("Failed to set initial terminal size: %v", err)
+	}
+
+	go c.streamOutput(stream)
 
 	return nil
 }
 
-func (c *Controller) streamOutput() {
+func (c *Controller) streamOutput(stream io.Reader) {
 	defer close(c.Output)
 
 	messages := make(chan *Message, 100)
 	go func() {
-		c.parser.ParseStream(c.ptmx, messages)
+		c.parser.ParseStream(stream, messages)
 		close(messages)
 	}()
 
@@ -125,51 +186,103 @@ func (c *Controller) streamOutput() {
 			c.SetSessionID(msg.SessionID)
 		}
 	}
+
+	// The stream closed, meaning the underlying process exited.
+	c.mu.Lock()
+	c.running = false
+	if c.stopTimer != nil {
+		c.stopTimer.Stop()
+		c.stopTimer = nil
+	}
+	cancel := c.cancel
+	c.mu.Unlock()
+
+	// Release the Start context only now that the process is actually
+	// gone. Calling it from Stop instead would cancel the ctx that
+	// exec.CommandContext armed back in runtime/local.Start, whose default
+	// Cancel is Process.Kill() - an instant SIGKILL that preempts the
+	// SIGTERM+stopGrace escalation below and defeats the whole point of
+	// giving claude a grace window to exit cleanly.
+	if cancel != nil {
+		cancel()
+	}
 }
 
 func (c *Controller) SendInput(input string) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if c.ptmx == nil {
-		return fmt.Errorf("PTY not available")
+	if c.stream == nil {
+		return fmt.Errorf("claude process not available")
 	}
 
-	_, err := io.WriteString(c.ptmx, input+"\n")
+	_, err := io.WriteString(c.stream, input+"\n")
 	return err
 }
 
-func (c *Controller) Stop() error {
+// Resize updates the terminal window size of a running session and
+// signals the process with SIGWINCH so the claude CLI re-renders for the
+// new dimensions.
+func (c *Controller) Resize(cols, rows uint16) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if !c.running {
-		return nil
-	}
+	c.winCols = cols
+	c.winRows = rows
 
-	if c.cancel != nil {
-		c.cancel()
+	if c.stream == nil {
+		return fmt.Errorf("claude process not available")
 	}
 
-	// Graceful termination via SIGTERM to process group
-	if c.cmd != nil && c.cmd.Process != nil {
-		syscall.Kill(-c.cmd.Process.Pid, syscall.SIGTERM)
+	if err := c.rt.Resize(cols, rows); err != nil {
+		return fmt.Errorf("failed to resize: %w", err)
 	}
 
+	c.rt.Signal(syscall.SIGWINCH)
+
 	return nil
 }
 
-func (c *Controller) ForceStop() error {
+// Stop signals the running process to terminate and schedules a SIGKILL
+// after stopGrace in case the claude CLI hangs on network I/O and never
+// exits. The escalation timer is cancelled automatically once
+// streamOutput observes the process exit. ctx is accepted for symmetry
+// with Start and to let future callers bound how long they're willing to
+// wait on the caller side; Stop itself does not block on process exit, and
+// deliberately does not cancel Start's context itself - that would
+// SIGKILL the process immediately (exec.CommandContext's default Cancel)
+// instead of giving it stopGrace to exit on SIGTERM. streamOutput cancels
+// it once the process has actually exited.
+func (c *Controller) Stop(ctx context.Context) error {
 	c.mu.Lock()
-	defer c.mu.Unlock()
 
-	if c.cmd != nil && c.cmd.Process != nil {
-		syscall.Kill(-c.cmd.Process.Pid, syscall.SIGKILL)
+	if !c.running {
+		c.mu.Unlock()
+		return nil
 	}
 
+	rt := c.rt
+	rt.Signal(syscall.SIGTERM)
+	grace := c.stopGrace
+	c.mu.Unlock()
+
+	timer := time.AfterFunc(grace, func() {
+		rt.Signal(syscall.SIGKILL)
+	})
+
+	c.mu.Lock()
+	c.stopTimer = timer
+	c.mu.Unlock()
+
 	return nil
 }
 
+func (c *Controller) ForceStop() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rt.Signal(syscall.SIGKILL)
+}
+
 func (c *Controller) IsRunning() bool {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -1,7 +1,14 @@
 package claude
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"syscall"
 	"testing"
+	"time"
+
+	"github.com/user/teleclaude/internal/runtime/local"
 )
 
 func TestControllerBuildArgs(t *testing.T) {
@@ -75,4 +82,154 @@ func TestNewController(t *testing.T) {
 	if ctrl.Output == nil {
 		t.Error("Output channel should be initialized")
 	}
+	if ctrl.winCols != DefaultWinCols {
+		t.Errorf("winCols = %d, want %d", ctrl.winCols, DefaultWinCols)
+	}
+	if ctrl.winRows != DefaultWinRows {
+		t.Errorf("winRows = %d, want %d", ctrl.winRows, DefaultWinRows)
+	}
+}
+
+func TestControllerSetWinSize(t *testing.T) {
+	ctrl := NewController("/home/user/app", 50, "acceptEdits")
+	ctrl.SetWinSize(120, 40)
+
+	if ctrl.winCols != 120 || ctrl.winRows != 40 {
+		t.Errorf("winCols/winRows = %d/%d, want 120/40", ctrl.winCols, ctrl.winRows)
+	}
+}
+
+func TestControllerSetStopGrace(t *testing.T) {
+	ctrl := NewController("/home/user/app", 50, "acceptEdits")
+
+	if ctrl.stopGrace != DefaultStopGrace {
+		t.Errorf("stopGrace = %v, want %v", ctrl.stopGrace, DefaultStopGrace)
+	}
+
+	ctrl.SetStopGrace(10 * time.Second)
+	if ctrl.stopGrace != 10*time.Second {
+		t.Errorf("stopGrace = %v, want 10s", ctrl.stopGrace)
+	}
+}
+
+func TestControllerStopWhenNotRunning(t *testing.T) {
+	ctrl := NewController("/home/user/app", 50, "acceptEdits")
+
+	if err := ctrl.Stop(context.Background()); err != nil {
+		t.Errorf("Stop() on a non-running controller should be a no-op, got error = %v", err)
+	}
+}
+
+func TestControllerResizeBeforeStart(t *testing.T) {
+	ctrl := NewController("/home/user/app", 50, "acceptEdits")
+
+	if err := ctrl.Resize(100, 30); err == nil {
+		t.Error("Resize() before Start() should fail since there is no PTY yet")
+	}
+}
+
+// TestControllerStopReapsProcessWithinGracePeriod starts a fake "claude"
+// (a shell script on PATH standing in for a long-lived claude invocation,
+// analogous to `sleep 3600`), calls Stop, and checks the process is gone
+// from the process table — not just that its PTY closed — within
+// stopGrace plus a small epsilon. Regresses the runtime extraction
+// dropping cmd.Wait(), which left every claude child a zombie.
+func TestControllerStopReapsProcessWithinGracePeriod(t *testing.T) {
+	binDir := t.TempDir()
+	script := filepath.Join(binDir, "claude")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\nexec sleep 3600\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	ctrl := NewController(t.TempDir(), 50, "acceptEdits")
+	ctrl.SetStopGrace(200 * time.Millisecond)
+
+	rt := local.New()
+	ctrl.SetRuntime(rt)
+
+	if err := ctrl.Start(context.Background(), "hello"); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	go func() {
+		for range ctrl.Output {
+		}
+	}()
+
+	pid := rt.Pid()
+	if pid == 0 {
+		t.Fatal("runtime never recorded a pid after Start()")
+	}
+
+	if err := ctrl.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+
+	deadline := time.Now().Add(ctrl.stopGrace + 2*time.Second)
+	for time.Now().Before(deadline) {
+		if syscall.Kill(pid, 0) != nil {
+			return // ESRCH: the process table entry is gone, i.e. actually reaped
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Errorf("process %d was not reaped within stopGrace + epsilon", pid)
+}
+
+// TestControllerStopActuallyGrantsStopGrace uses a fake "claude" that
+// ignores SIGTERM, so only the SIGKILL escalation after stopGrace can end
+// it. A sleep-based child (as above) would die on SIGTERM too and
+// couldn't tell an honored grace window apart from Stop instantly
+// SIGKILL-ing the process via ctx cancellation. Asserts the process is
+// still alive shortly after Stop() returns (no instant kill) and reaped
+// only once stopGrace has actually elapsed.
+func TestControllerStopActuallyGrantsStopGrace(t *testing.T) {
+	binDir := t.TempDir()
+	script := filepath.Join(binDir, "claude")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\ntrap '' TERM\nsleep 3600\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	ctrl := NewController(t.TempDir(), 50, "acceptEdits")
+	const grace = 300 * time.Millisecond
+	ctrl.SetStopGrace(grace)
+
+	rt := local.New()
+	ctrl.SetRuntime(rt)
+
+	if err := ctrl.Start(context.Background(), "hello"); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	go func() {
+		for range ctrl.Output {
+		}
+	}()
+
+	pid := rt.Pid()
+	if pid == 0 {
+		t.Fatal("runtime never recorded a pid after Start()")
+	}
+
+	stopped := time.Now()
+	if err := ctrl.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+
+	// Give the TERM-ignoring child a moment to prove it's still there -
+	// well before stopGrace elapses. If Stop still cancelled Start's ctx,
+	// exec.CommandContext's default Cancel (Process.Kill) would have
+	// SIGKILL'd it here already.
+	time.Sleep(grace / 2)
+	if syscall.Kill(pid, 0) != nil {
+		t.Fatalf("process %d was reaped before stopGrace (%v) elapsed; SIGTERM-ignoring child should only die on the SIGKILL escalation", pid, grace)
+	}
+
+	deadline := stopped.Add(grace + 2*time.Second)
+	for time.Now().Before(deadline) {
+		if syscall.Kill(pid, 0) != nil {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Errorf("process %d was not reaped within stopGrace + epsilon", pid)
 }
@@ -15,8 +15,11 @@ func NewParser() *Parser {
 
 func (p *Parser) ParseStream(reader io.Reader, messages chan<- *Message) {
 	scanner := bufio.NewScanner(reader)
-	// Increase buffer for large messages
-	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	// Increase buffer for large messages; tool_result lines carrying a full
+	// file read or a long bash log can run well past the default 64KB
+	// token limit, and those now stream out as a document via
+	// Formatter.ChunkOrUpload instead of being dropped here.
+	scanner.Buffer(make([]byte, 64*1024), 8*1024*1024)
 
 	for scanner.Scan() {
 		line := scanner.Bytes()
@@ -1,31 +1,207 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"regexp"
+	"strconv"
+	"time"
 
+	"github.com/user/teleclaude/internal/approval"
 	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
-	AllowedUsers []int64           `yaml:"allowed_users"`
-	Projects     map[string]string `yaml:"projects"`
-	Claude       ClaudeConfig      `yaml:"claude"`
-	Approval     ApprovalConfig    `yaml:"approval"`
-	Streaming    StreamingConfig   `yaml:"streaming"`
+	// AllowedUsers and Admins are the pre-role-model way to list who may
+	// use the bot: deprecated in favor of Users, but still read — when
+	// Users is empty, applyDefaults synthesizes it from these two so an
+	// existing config.yaml keeps working unmodified.
+	AllowedUsers []int64 `yaml:"allowed_users"`
+	Admins       []int64 `yaml:"admins"`
+
+	// Users is the role-aware replacement for AllowedUsers/Admins: each
+	// entry grants a user access at a specific Role and may carry its own
+	// standing approval overrides (e.g. one user always requires approval
+	// for Write, another never for "git status").
+	Users []UserEntry `yaml:"users"`
+
+	Projects   map[string]string `yaml:"projects"`
+	Transports []string          `yaml:"transports"` // "telegram", "xmpp"; defaults to ["telegram"]
+	Claude     ClaudeConfig      `yaml:"claude"`
+	Approval   ApprovalConfig    `yaml:"approval"`
+	Streaming  StreamingConfig   `yaml:"streaming"`
+	Storage    StorageConfig     `yaml:"storage"`
+	Sessions   SessionsConfig    `yaml:"sessions"`
+	XMPP       XMPPConfig        `yaml:"xmpp"`
+	Voice      VoiceConfig       `yaml:"voice"`
+	Runtime    RuntimeConfig     `yaml:"runtime"`
+	RateLimit  RateLimitConfig   `yaml:"rate_limit"`
+
+	// ApprovalRules drives the approval.Engine: an ordered list of
+	// match/action rules. When left empty, it's synthesized from
+	// Approval.RequireFor's tool-name shortcut; Bash-specific dangerous
+	// commands are covered by Approval.Policies instead, not by a seeded
+	// command_regex list here.
+	ApprovalRules []approval.Rule `yaml:"approval_rules"`
+}
+
+// UserEntry grants a Telegram user access at a specific Role, with optional
+// standing approval overrides layered on top of the global approval_rules
+// (e.g. this user always requires approval for Write, that one never for
+// "git status"). When config.yaml leaves users: empty, applyDefaults
+// synthesizes one UserEntry per AllowedUsers/Admins ID instead, defaulting
+// to RoleOperator and upgrading to RoleAdmin for IDs also listed under
+// admins:.
+type UserEntry struct {
+	ID                int64           `yaml:"id"`
+	Role              approval.Role   `yaml:"role"`
+	ApprovalOverrides []approval.Rule `yaml:"approval_overrides"`
+}
+
+// VoiceConfig configures the optional voice subsystem: transcribing
+// incoming Telegram voice notes and speaking approval prompts back as a
+// voice note. Binary paths default to the bare command name, resolved via
+// PATH, when left blank.
+type VoiceConfig struct {
+	Enabled      bool   `yaml:"enabled"`
+	WhisperBin   string `yaml:"whisper_bin"`
+	WhisperModel string `yaml:"whisper_model"`
+	PiperBin     string `yaml:"piper_bin"`
+	PiperModel   string `yaml:"piper_model"`
+	FFmpegBin    string `yaml:"ffmpeg_bin"`
+}
+
+// RuntimeConfig selects and configures the runtime.Runtime backend
+// claude.Controller launches each session through: the host process
+// directly ("local", the default) or a per-session sandbox container
+// ("docker"). CPULimit/MemoryLimit/Network/Image are only read when
+// Backend is "docker".
+type RuntimeConfig struct {
+	Backend     string `yaml:"backend"` // "local" (default) or "docker"
+	Image       string `yaml:"image"`
+	CPULimit    string `yaml:"cpu_limit"`
+	MemoryLimit string `yaml:"memory_limit"`
+	Network     string `yaml:"network"` // e.g. "none" or "bridge"
+}
+
+// RateLimitConfig caps how many messages a user can send per minute and
+// drives auth.AbuseTracker's automatic short-duration bans for users who
+// rack up denied approvals or failed messages in a short window. A negative
+// MessagesPerMinute or AbuseThreshold disables that check; zero (unset)
+// means the default applyDefaults fills in.
+type RateLimitConfig struct {
+	MessagesPerMinute int      `yaml:"messages_per_minute"`
+	AbuseThreshold    int      `yaml:"abuse_threshold"`
+	AbuseWindow       Duration `yaml:"abuse_window"`
+	AutoBanTTL        Duration `yaml:"auto_ban_ttl"`
+}
+
+// XMPPConfig configures the optional XMPP transport. Only read when
+// "xmpp" appears in Transports.
+type XMPPConfig struct {
+	JID      string `yaml:"jid"`
+	Password string `yaml:"password"`
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+}
+
+// Duration wraps time.Duration so config files can write "24h" / "1m"
+// instead of a raw nanosecond count.
+type Duration struct {
+	time.Duration
+}
+
+func (d *Duration) UnmarshalYAML(value *yaml.Node) error {
+	var s string
+	if err := value.Decode(&s); err != nil {
+		return err
+	}
+
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q: %w", s, err)
+	}
+	d.Duration = parsed
+	return nil
+}
+
+// SessionsConfig governs the session janitor: how long an idle session
+// lives, how often it's renewed/swept, and how long an expired session's
+// record is kept before deletion.
+type SessionsConfig struct {
+	TTL           Duration `yaml:"ttl"`
+	RenewInterval Duration `yaml:"renew_interval"`
+	Retention     Duration `yaml:"retention"`
+}
+
+// StorageConfig selects and configures a pluggable KV backend. It's shared
+// by Config.Storage (session.SessionStore) and ApprovalConfig.Storage
+// (approval.RequestStore) since both pick from the same consul/etcd/file
+// family of backends.
+type StorageConfig struct {
+	Backend   string    `yaml:"backend"` // "file" (default), "badger", "consul", or "etcd"
+	Endpoints []string  `yaml:"endpoints"`
+	Prefix    string    `yaml:"prefix"`
+	Path      string    `yaml:"path"` // backend=badger: directory name under the data dir, default "badger"
+	TLS       TLSConfig `yaml:"tls"`
+}
+
+type TLSConfig struct {
+	Enabled  bool   `yaml:"enabled"`
+	CAFile   string `yaml:"ca_file"`
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
 }
 
 type ClaudeConfig struct {
 	MaxTurns       int    `yaml:"max_turns"`
 	PermissionMode string `yaml:"permission_mode"`
+	PTYCols        int    `yaml:"pty_cols"`
+	PTYRows        int    `yaml:"pty_rows"`
+	StopGraceMs    int    `yaml:"stop_grace_ms"`
 }
 
 type ApprovalConfig struct {
 	RequireFor []string `yaml:"require_for"`
+
+	// Policies drives approval.Engine's shell-aware checks for Bash
+	// commands: each entry is matched against a parsed command's own
+	// binary/flags/args rather than a regex over the raw string. When
+	// left empty, it's synthesized from
+	// approval.DefaultDangerousCommandPolicies so existing config.yaml
+	// files gain the stricter matching without any changes.
+	Policies []approval.Policy `yaml:"policies"`
+
+	// Persist enables approval.Workflow's RequestStore, so a pending
+	// approval survives a bot restart and is replayed instead of silently
+	// lost. Off by default since the default "file" backend adds a file
+	// write per request.
+	Persist bool `yaml:"persist"`
+
+	// Storage selects the RequestStore backend Persist attaches, mirroring
+	// Config.Storage's backend selection for sessions. Backend "badger" is
+	// not supported here (only "file", "consul", and "etcd"); Path is not
+	// read, and a zero-value Backend means "file".
+	Storage StorageConfig `yaml:"storage"`
 }
 
 type StreamingConfig struct {
-	EditThrottleMs int `yaml:"edit_throttle_ms"`
-	ChunkSize      int `yaml:"chunk_size"`
+	EditThrottleMs    int   `yaml:"edit_throttle_ms"`
+	ChunkSize         int   `yaml:"chunk_size"`
+	NativeEdits       *bool `yaml:"native_edits"` // defaults to true; set false to always append a new message instead of editing
+	MinEditIntervalMs int   `yaml:"min_edit_interval_ms"`
+
+	// UploadThresholdBytes is the output size above which ChunkOrUpload
+	// sends a document attachment instead of a run of split messages.
+	UploadThresholdBytes int `yaml:"upload_threshold_bytes"`
+	Workers              int `yaml:"workers"`   // ChunkOrUpload assembler goroutines
+	PartSize             int `yaml:"part_size"` // bytes each assembler worker handles
+}
+
+// UsesNativeEdits reports whether streaming should edit messages in place
+// rather than always appending a new one. Defaults to true.
+func (s StreamingConfig) UsesNativeEdits() bool {
+	return s.NativeEdits == nil || *s.NativeEdits
 }
 
 func Load(path string) (*Config, error) {
@@ -50,21 +226,158 @@ func applyDefaults(cfg *Config) {
 	if cfg.Claude.PermissionMode == "" {
 		cfg.Claude.PermissionMode = "acceptEdits"
 	}
+	if cfg.Claude.PTYCols == 0 {
+		cfg.Claude.PTYCols = 200
+	}
+	if cfg.Claude.PTYRows == 0 {
+		cfg.Claude.PTYRows = 50
+	}
+	if cfg.Claude.StopGraceMs == 0 {
+		cfg.Claude.StopGraceMs = 5000
+	}
 	if cfg.Streaming.EditThrottleMs == 0 {
 		cfg.Streaming.EditThrottleMs = 1000
 	}
 	if cfg.Streaming.ChunkSize == 0 {
 		cfg.Streaming.ChunkSize = 3800
 	}
+	if cfg.Streaming.MinEditIntervalMs == 0 {
+		cfg.Streaming.MinEditIntervalMs = 1000
+	}
+	if cfg.Streaming.UploadThresholdBytes == 0 {
+		cfg.Streaming.UploadThresholdBytes = 16000
+	}
+	if cfg.Streaming.Workers == 0 {
+		cfg.Streaming.Workers = 4
+	}
+	if cfg.Streaming.PartSize == 0 {
+		cfg.Streaming.PartSize = 64 * 1024
+	}
 	if cfg.Projects == nil {
 		cfg.Projects = make(map[string]string)
 	}
+	if len(cfg.Users) == 0 {
+		for _, id := range cfg.AllowedUsers {
+			cfg.Users = append(cfg.Users, UserEntry{ID: id, Role: approval.RoleOperator})
+		}
+		for _, id := range cfg.Admins {
+			upgraded := false
+			for i := range cfg.Users {
+				if cfg.Users[i].ID == id {
+					cfg.Users[i].Role = approval.RoleAdmin
+					upgraded = true
+					break
+				}
+			}
+			if !upgraded {
+				cfg.Users = append(cfg.Users, UserEntry{ID: id, Role: approval.RoleAdmin})
+			}
+		}
+	}
 	if cfg.Approval.RequireFor == nil {
 		cfg.Approval.RequireFor = []string{"Bash", "delete", "git push", "git force"}
 	}
+	if len(cfg.ApprovalRules) == 0 {
+		for _, name := range cfg.Approval.RequireFor {
+			cfg.ApprovalRules = append(cfg.ApprovalRules,
+				approval.Rule{
+					Name:   "require_for:" + name + ":tool",
+					Match:  approval.Match{Tool: name},
+					Action: approval.ActionRequire,
+				},
+				approval.Rule{
+					Name:   "require_for:" + name + ":bash",
+					Match:  approval.Match{Tool: "Bash", CommandRegex: "(?i)" + regexp.QuoteMeta(name)},
+					Action: approval.ActionRequire,
+				},
+			)
+		}
+		// Dangerous Bash commands are no longer seeded here as
+		// approval.DefaultDangerousCommandRules' command_regex patterns —
+		// that's exactly the raw-string substring matching
+		// Approval.Policies' shell-aware checks below replace it with.
+		// Keeping both would mean false positives like
+		// `echo "rm is scary"` still tripped approval.
+	}
+	if len(cfg.Approval.Policies) == 0 {
+		cfg.Approval.Policies = approval.DefaultDangerousCommandPolicies()
+	}
+	for _, u := range cfg.Users {
+		for _, r := range u.ApprovalOverrides {
+			r.Match.UserID = strconv.FormatInt(u.ID, 10)
+			cfg.ApprovalRules = append(cfg.ApprovalRules, r)
+		}
+	}
+	if cfg.Storage.Backend == "" {
+		cfg.Storage.Backend = "file"
+	}
+	if cfg.Storage.Prefix == "" {
+		cfg.Storage.Prefix = "teleclaude/sessions"
+	}
+	if cfg.Storage.Path == "" {
+		cfg.Storage.Path = "badger"
+	}
+	if cfg.Sessions.TTL.Duration == 0 {
+		cfg.Sessions.TTL.Duration = 24 * time.Hour
+	}
+	if cfg.Sessions.RenewInterval.Duration == 0 {
+		cfg.Sessions.RenewInterval.Duration = time.Minute
+	}
+	if cfg.Sessions.Retention.Duration == 0 {
+		cfg.Sessions.Retention.Duration = 7 * 24 * time.Hour
+	}
+	if len(cfg.Transports) == 0 {
+		cfg.Transports = []string{"telegram"}
+	}
+	if cfg.XMPP.Port == 0 {
+		cfg.XMPP.Port = 5222
+	}
+	if cfg.Voice.WhisperBin == "" {
+		cfg.Voice.WhisperBin = "whisper-cli"
+	}
+	if cfg.Voice.PiperBin == "" {
+		cfg.Voice.PiperBin = "piper"
+	}
+	if cfg.Voice.FFmpegBin == "" {
+		cfg.Voice.FFmpegBin = "ffmpeg"
+	}
+	if cfg.Runtime.Backend == "" {
+		cfg.Runtime.Backend = "local"
+	}
+	if cfg.RateLimit.MessagesPerMinute == 0 {
+		cfg.RateLimit.MessagesPerMinute = 20
+	}
+	if cfg.RateLimit.AbuseThreshold == 0 {
+		cfg.RateLimit.AbuseThreshold = 5
+	}
+	if cfg.RateLimit.AbuseWindow.Duration == 0 {
+		cfg.RateLimit.AbuseWindow.Duration = 5 * time.Minute
+	}
+	if cfg.RateLimit.AutoBanTTL.Duration == 0 {
+		cfg.RateLimit.AutoBanTTL.Duration = 15 * time.Minute
+	}
+}
+
+// HasTransport reports whether name is listed under the transports: config
+// key.
+func (c *Config) HasTransport(name string) bool {
+	for _, t := range c.Transports {
+		if t == name {
+			return true
+		}
+	}
+	return false
 }
 
+// IsUserAllowed reports whether userID may use the bot at all: listed in
+// Users (or, for a config that hasn't gone through applyDefaults, the
+// legacy allowed_users: key directly).
 func (c *Config) IsUserAllowed(userID int64) bool {
+	for _, u := range c.Users {
+		if u.ID == userID {
+			return true
+		}
+	}
 	for _, id := range c.AllowedUsers {
 		if id == userID {
 			return true
@@ -72,3 +385,40 @@ func (c *Config) IsUserAllowed(userID int64) bool {
 	}
 	return false
 }
+
+// IsAdmin reports whether userID holds approval.RoleAdmin, via Users (or,
+// for a config that hasn't gone through applyDefaults, the legacy admins:
+// key directly).
+func (c *Config) IsAdmin(userID int64) bool {
+	if role, ok := c.UserRole(userID); ok {
+		return role == approval.RoleAdmin
+	}
+	for _, id := range c.Admins {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// UserRole returns userID's configured Role and whether it's listed in
+// Users at all.
+func (c *Config) UserRole(userID int64) (approval.Role, bool) {
+	for _, u := range c.Users {
+		if u.ID == userID {
+			return u.Role, true
+		}
+	}
+	return "", false
+}
+
+// UserApprovalOverrides returns userID's standing per-user approval rules,
+// or nil if they have none.
+func (c *Config) UserApprovalOverrides(userID int64) []approval.Rule {
+	for _, u := range c.Users {
+		if u.ID == userID {
+			return u.ApprovalOverrides
+		}
+	}
+	return nil
+}
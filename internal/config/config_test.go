@@ -4,6 +4,8 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/user/teleclaude/internal/approval"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -85,6 +87,12 @@ allowed_users:
 	if cfg.Streaming.ChunkSize != 3800 {
 		t.Errorf("Streaming.ChunkSize default = %d, want 3800", cfg.Streaming.ChunkSize)
 	}
+	if cfg.RateLimit.MessagesPerMinute != 20 {
+		t.Errorf("RateLimit.MessagesPerMinute default = %d, want 20", cfg.RateLimit.MessagesPerMinute)
+	}
+	if cfg.RateLimit.AbuseThreshold != 5 {
+		t.Errorf("RateLimit.AbuseThreshold default = %d, want 5", cfg.RateLimit.AbuseThreshold)
+	}
 }
 
 func TestIsUserAllowed(t *testing.T) {
@@ -99,3 +107,88 @@ func TestIsUserAllowed(t *testing.T) {
 		t.Error("IsUserAllowed(99999999) = true, want false")
 	}
 }
+
+func TestUsersSynthesizedFromLegacyFields(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	configContent := `
+allowed_users:
+  - 111
+  - 222
+admins:
+  - 222
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	role, ok := cfg.UserRole(111)
+	if !ok || role != approval.RoleOperator {
+		t.Errorf("UserRole(111) = %q, %v; want operator, true", role, ok)
+	}
+
+	role, ok = cfg.UserRole(222)
+	if !ok || role != approval.RoleAdmin {
+		t.Errorf("UserRole(222) = %q, %v; want admin, true", role, ok)
+	}
+
+	if !cfg.IsAdmin(222) {
+		t.Error("IsAdmin(222) = false, want true")
+	}
+	if cfg.IsAdmin(111) {
+		t.Error("IsAdmin(111) = true, want false")
+	}
+}
+
+func TestApprovalPoliciesDefaulted(t *testing.T) {
+	cfg := &Config{}
+	applyDefaults(cfg)
+
+	if len(cfg.Approval.Policies) == 0 {
+		t.Fatal("expected Approval.Policies to be seeded with defaults")
+	}
+
+	var foundRm bool
+	for _, p := range cfg.Approval.Policies {
+		if p.Binary == "rm" {
+			foundRm = true
+		}
+	}
+	if !foundRm {
+		t.Error("expected a default policy for rm")
+	}
+}
+
+func TestUserApprovalOverridesScopedToUser(t *testing.T) {
+	cfg := &Config{
+		Users: []UserEntry{
+			{ID: 111, Role: approval.RoleOperator, ApprovalOverrides: []approval.Rule{
+				{Name: "always-ask-write", Match: approval.Match{Tool: "Write"}, Action: approval.ActionRequire},
+			}},
+		},
+	}
+	applyDefaults(cfg)
+
+	if len(cfg.ApprovalRules) == 0 {
+		t.Fatal("expected the per-user override to be flattened into ApprovalRules")
+	}
+
+	var found bool
+	for _, r := range cfg.ApprovalRules {
+		if r.Name == "always-ask-write" {
+			found = true
+			if r.Match.UserID != "111" {
+				t.Errorf("UserID = %q, want 111", r.Match.UserID)
+			}
+		}
+	}
+	if !found {
+		t.Error("always-ask-write rule not found in ApprovalRules")
+	}
+}
@@ -0,0 +1,176 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// reloadDebounce coalesces a burst of write events from a single editor
+// save (many editors write via a temp file + rename, firing more than one
+// event) into one reload.
+const reloadDebounce = 500 * time.Millisecond
+
+// Diff summarizes what changed between two successfully loaded configs, so
+// Watcher's reload callback can tell an admin what actually moved instead
+// of just "config changed".
+type Diff struct {
+	AddedProjects   []string
+	RemovedProjects []string
+	RuleCountBefore int
+	RuleCountAfter  int
+}
+
+// IsEmpty reports whether the diff has nothing worth mentioning beyond the
+// reload itself.
+func (d Diff) IsEmpty() bool {
+	return len(d.AddedProjects) == 0 && len(d.RemovedProjects) == 0 && d.RuleCountBefore == d.RuleCountAfter
+}
+
+// Watcher holds the active Config behind an atomic pointer, so Current()
+// always returns the latest successfully loaded version without callers
+// needing to coordinate on a lock or hold onto a stale captured pointer.
+type Watcher struct {
+	path     string
+	validate func(*Config) error
+	current  atomic.Pointer[Config]
+}
+
+// NewWatcher wraps an already-loaded Config (e.g. from Load) so it can be
+// hot-reloaded later via Start, without requiring every caller of NewBot to
+// go through the filesystem watch setup. validate is run against a
+// reloaded config before it's swapped in — e.g. checking its
+// approval_rules/approval.policies actually compile — so a config that
+// parses but fails validate leaves the old Config (and whatever state was
+// built from it) fully in place instead of going live with only part of
+// the new config. validate may be nil to skip this check.
+func NewWatcher(cfg *Config, validate func(*Config) error) *Watcher {
+	w := &Watcher{validate: validate}
+	w.current.Store(cfg)
+	return w
+}
+
+// Current returns the active Config. Safe to call from any goroutine.
+func (w *Watcher) Current() *Config {
+	return w.current.Load()
+}
+
+// Start watches path for write events and reloads on each one, debounced by
+// reloadDebounce. onReload is called with a summary of what changed after a
+// successful reload; onError is called instead, with the old Config left in
+// place, when the new file fails to parse. Both may be nil. Start returns
+// once the watch is established; the reload loop runs until ctx is done.
+func (w *Watcher) Start(ctx context.Context, path string, onReload func(Diff), onError func(error)) error {
+	w.path = path
+
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("config: failed to start watcher: %w", err)
+	}
+	if err := fw.Add(path); err != nil {
+		fw.Close()
+		return fmt.Errorf("config: failed to watch %s: %w", path, err)
+	}
+
+	go func() {
+		defer fw.Close()
+
+		var debounce *time.Timer
+		for {
+			select {
+			case <-ctx.Done():
+				if debounce != nil {
+					debounce.Stop()
+				}
+				return
+
+			case event, ok := <-fw.Events:
+				if !ok {
+					return
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+					continue
+				}
+				if debounce == nil {
+					debounce = time.AfterFunc(reloadDebounce, func() { w.reload(onReload, onError) })
+				} else {
+					debounce.Reset(reloadDebounce)
+				}
+
+			case err, ok := <-fw.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("config: watcher error: %v", err)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Reload re-reads path immediately, the same codepath Start's debounced
+// file-event handler uses. Exposed for the /reload admin command.
+func (w *Watcher) Reload() (Diff, error) {
+	var diff Diff
+	var reloadErr error
+	w.reload(func(d Diff) { diff = d }, func(err error) { reloadErr = err })
+	return diff, reloadErr
+}
+
+func (w *Watcher) reload(onReload func(Diff), onError func(error)) {
+	next, err := Load(w.path)
+	if err != nil {
+		if onError != nil {
+			onError(err)
+		}
+		return
+	}
+
+	if w.validate != nil {
+		if err := w.validate(next); err != nil {
+			if onError != nil {
+				onError(err)
+			}
+			return
+		}
+	}
+
+	prev := w.current.Swap(next)
+	if onReload != nil {
+		onReload(diffConfigs(prev, next))
+	}
+}
+
+// diffConfigs summarizes what moved between two loaded configs: the
+// project list and how many approval rules compile, the two things an
+// admin would want to know happened after a reload.
+func diffConfigs(prev, next *Config) Diff {
+	diff := Diff{
+		RuleCountBefore: len(prev.ApprovalRules),
+		RuleCountAfter:  len(next.ApprovalRules),
+	}
+
+	prevProjects := make(map[string]bool, len(prev.Projects))
+	for name := range prev.Projects {
+		prevProjects[name] = true
+	}
+	nextProjects := make(map[string]bool, len(next.Projects))
+	for name := range next.Projects {
+		nextProjects[name] = true
+		if !prevProjects[name] {
+			diff.AddedProjects = append(diff.AddedProjects, name)
+		}
+	}
+	for name := range prevProjects {
+		if !nextProjects[name] {
+			diff.RemovedProjects = append(diff.RemovedProjects, name)
+		}
+	}
+
+	return diff
+}
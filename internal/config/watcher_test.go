@@ -0,0 +1,129 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatcherReloadsOnWrite(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	if err := os.WriteFile(configPath, []byte(`
+allowed_users:
+  - 111
+projects:
+  app1: /home/user/app1
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	watcher := NewWatcher(cfg, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	diffs := make(chan Diff, 1)
+	if err := watcher.Start(ctx, configPath, func(d Diff) { diffs <- d }, nil); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	if err := os.WriteFile(configPath, []byte(`
+allowed_users:
+  - 111
+projects:
+  app1: /home/user/app1
+  app2: /home/user/app2
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case diff := <-diffs:
+		if len(diff.AddedProjects) != 1 || diff.AddedProjects[0] != "app2" {
+			t.Errorf("AddedProjects = %v, want [app2]", diff.AddedProjects)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for reload")
+	}
+
+	if watcher.Current().Projects["app2"] != "/home/user/app2" {
+		t.Error("Current() didn't pick up the reloaded config")
+	}
+}
+
+func TestWatcherKeepsOldConfigOnParseError(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	if err := os.WriteFile(configPath, []byte(`
+allowed_users:
+  - 111
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	watcher := NewWatcher(cfg, nil)
+	watcher.path = configPath // normally set by Start
+
+	if err := os.WriteFile(configPath, []byte("not: valid: yaml: [[["), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := watcher.Reload(); err == nil {
+		t.Error("expected Reload() to surface the parse error")
+	}
+
+	if !watcher.Current().IsUserAllowed(111) {
+		t.Error("expected the old config to remain active after a failed reload")
+	}
+}
+
+func TestWatcherKeepsOldConfigOnValidateError(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	if err := os.WriteFile(configPath, []byte(`
+allowed_users:
+  - 111
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	wantErr := errors.New("approval_rules rejected")
+	watcher := NewWatcher(cfg, func(*Config) error { return wantErr })
+	watcher.path = configPath // normally set by Start
+
+	if err := os.WriteFile(configPath, []byte(`
+allowed_users:
+  - 111
+  - 222
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := watcher.Reload(); !errors.Is(err, wantErr) {
+		t.Errorf("Reload() error = %v, want %v", err, wantErr)
+	}
+
+	if watcher.Current().IsUserAllowed(222) {
+		t.Error("expected the old config to remain active after a failed validate, not the reloaded-but-invalid one")
+	}
+}
@@ -0,0 +1,162 @@
+// Package docker runs the Claude CLI inside a per-session sandbox
+// container instead of as a child process on the host, bind-mounting the
+// session's project directory and applying CPU/memory/network limits.
+package docker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+
+	"github.com/creack/pty"
+	"github.com/user/teleclaude/internal/runtime"
+	"github.com/user/teleclaude/internal/session"
+)
+
+// Config configures the docker runtime's resource limits and base image,
+// shared across every session that uses it.
+type Config struct {
+	Image       string // default "teleclaude-sandbox:latest"
+	CPULimit    string // docker --cpus, e.g. "1.5"; default "2"
+	MemoryLimit string // docker --memory, e.g. "512m"; default "2g"
+	Network     string // docker --network, e.g. "none" or "bridge"; default "none"
+}
+
+func (c Config) withDefaults() Config {
+	if c.Image == "" {
+		c.Image = "teleclaude-sandbox:latest"
+	}
+	if c.CPULimit == "" {
+		c.CPULimit = "2"
+	}
+	if c.MemoryLimit == "" {
+		c.MemoryLimit = "2g"
+	}
+	if c.Network == "" {
+		c.Network = "none"
+	}
+	return c
+}
+
+// Runtime runs claude inside a long-lived sandbox container: the first
+// Start for a session creates the container (idling on "sleep infinity")
+// and execs claude inside it; later Starts for the same session (sharing
+// sess.ContainerID) reuse the same container, so working directory state
+// a session built up survives across turns and bot restarts.
+type Runtime struct {
+	cfg Config
+
+	cmd         *exec.Cmd
+	ptmx        *os.File
+	containerID string
+}
+
+var _ runtime.Runtime = (*Runtime)(nil)
+
+// New creates a docker runtime sharing cfg's resource limits across every
+// session it sandboxes.
+func New(cfg Config) *Runtime {
+	return &Runtime{cfg: cfg.withDefaults()}
+}
+
+func (r *Runtime) Start(ctx context.Context, sess *session.Session, args []string) (io.ReadWriter, error) {
+	// A Runtime is reused across turns for the same session; close out the
+	// previous turn's exec PTY fd before replacing it. The sandbox
+	// container itself is left running.
+	if r.ptmx != nil {
+		r.ptmx.Close()
+	}
+
+	if sess.ContainerID == "" || !r.containerRunning(ctx, sess.ContainerID) {
+		id, err := r.createContainer(ctx, sess)
+		if err != nil {
+			return nil, err
+		}
+		sess.ContainerID = id
+		sess.NetworkMode = r.cfg.Network
+	}
+
+	execArgs := append([]string{"exec", "-i", sess.ContainerID, "claude"}, args...)
+	cmd := exec.CommandContext(ctx, "docker", execArgs...)
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+
+	ptmx, err := pty.Start(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("runtime/docker: failed to exec claude in container %s: %w", sess.ContainerID, err)
+	}
+
+	r.cmd = cmd
+	r.ptmx = ptmx
+	r.containerID = sess.ContainerID
+
+	// pty.Start forks the `docker exec` client but never reaps it; without
+	// a Wait call here it becomes a zombie once it exits, same as
+	// runtime/local.
+	go func() {
+		cmd.Wait()
+	}()
+
+	return ptmx, nil
+}
+
+// createContainer starts a sandbox container that bind-mounts the
+// session's project directory read-write and just idles, so Start can
+// exec claude into it once (and again on later turns).
+func (r *Runtime) createContainer(ctx context.Context, sess *session.Session) (string, error) {
+	args := []string{
+		"run", "-d",
+		"--memory", r.cfg.MemoryLimit,
+		"--cpus", r.cfg.CPULimit,
+		"--network", r.cfg.Network,
+		"-v", sess.ProjectPath + ":" + sess.ProjectPath,
+		"-w", sess.ProjectPath,
+		r.cfg.Image,
+		"sleep", "infinity",
+	}
+
+	out, err := exec.CommandContext(ctx, "docker", args...).Output()
+	if err != nil {
+		return "", fmt.Errorf("runtime/docker: failed to create sandbox container: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func (r *Runtime) containerRunning(ctx context.Context, id string) bool {
+	out, err := exec.CommandContext(ctx, "docker", "inspect", "-f", "{{.State.Running}}", id).Output()
+	return err == nil && strings.TrimSpace(string(out)) == "true"
+}
+
+// Signal is best-effort: it only reaches the local `docker exec` client
+// process, since docker exec -i without a remote tty doesn't forward
+// signals into the container. Close (docker stop) is what reliably ends
+// the sandboxed claude process.
+func (r *Runtime) Signal(sig syscall.Signal) error {
+	if r.cmd == nil || r.cmd.Process == nil {
+		return fmt.Errorf("runtime/docker: no running exec")
+	}
+	return syscall.Kill(-r.cmd.Process.Pid, sig)
+}
+
+func (r *Runtime) Resize(cols, rows uint16) error {
+	if r.ptmx == nil {
+		return fmt.Errorf("runtime/docker: no PTY available")
+	}
+	return pty.Setsize(r.ptmx, &pty.Winsize{Cols: cols, Rows: rows})
+}
+
+// Close stops (but does not remove) the sandbox container, so a future
+// Start with the same sess.ContainerID can detect it's no longer running
+// and create a replacement.
+func (r *Runtime) Close() error {
+	if r.ptmx != nil {
+		r.ptmx.Close()
+	}
+	if r.containerID == "" {
+		return nil
+	}
+	return exec.Command("docker", "stop", r.containerID).Run()
+}
@@ -0,0 +1,55 @@
+package docker
+
+import "testing"
+
+func TestConfigWithDefaults(t *testing.T) {
+	cfg := Config{}.withDefaults()
+
+	if cfg.Image != "teleclaude-sandbox:latest" {
+		t.Errorf("Image default = %q", cfg.Image)
+	}
+	if cfg.CPULimit != "2" {
+		t.Errorf("CPULimit default = %q", cfg.CPULimit)
+	}
+	if cfg.MemoryLimit != "2g" {
+		t.Errorf("MemoryLimit default = %q", cfg.MemoryLimit)
+	}
+	if cfg.Network != "none" {
+		t.Errorf("Network default = %q", cfg.Network)
+	}
+}
+
+func TestConfigWithDefaultsPreservesOverrides(t *testing.T) {
+	cfg := Config{Image: "custom:latest", Network: "bridge"}.withDefaults()
+
+	if cfg.Image != "custom:latest" {
+		t.Errorf("Image = %q, want override preserved", cfg.Image)
+	}
+	if cfg.Network != "bridge" {
+		t.Errorf("Network = %q, want override preserved", cfg.Network)
+	}
+	if cfg.CPULimit != "2" {
+		t.Errorf("CPULimit = %q, want default filled in", cfg.CPULimit)
+	}
+}
+
+func TestRuntimeSignalBeforeStart(t *testing.T) {
+	r := New(Config{})
+	if err := r.Signal(0); err == nil {
+		t.Error("expected error signaling before Start")
+	}
+}
+
+func TestRuntimeResizeBeforeStart(t *testing.T) {
+	r := New(Config{})
+	if err := r.Resize(80, 24); err == nil {
+		t.Error("expected error resizing before Start")
+	}
+}
+
+func TestRuntimeCloseBeforeStart(t *testing.T) {
+	r := New(Config{})
+	if err := r.Close(); err != nil {
+		t.Errorf("expected Close before Start (no container) to be a no-op, got %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+// Package local runs the Claude CLI as a plain child process on the host
+// inside a PTY — teleclaude's original behavior, before runtime.Runtime
+// let a session opt into an isolated container instead.
+package local
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"syscall"
+
+	"github.com/creack/pty"
+	"github.com/user/teleclaude/internal/runtime"
+	"github.com/user/teleclaude/internal/session"
+)
+
+// Runtime is the host-process runtime.Runtime implementation. Each
+// claude.Controller owns its own instance, one process at a time,
+// mirroring Controller's own single-use lifetime.
+type Runtime struct {
+	cmd  *exec.Cmd
+	ptmx *os.File
+}
+
+var _ runtime.Runtime = (*Runtime)(nil)
+
+// New creates a local runtime ready for Start.
+func New() *Runtime {
+	return &Runtime{}
+}
+
+func (r *Runtime) Start(ctx context.Context, sess *session.Session, args []string) (io.ReadWriter, error) {
+	// A Runtime is reused across turns when its owning Controller is
+	// reused; close out the previous turn's PTY fd before replacing it.
+	if r.ptmx != nil {
+		r.ptmx.Close()
+	}
+
+	cmd := exec.CommandContext(ctx, "claude", args...)
+	cmd.Dir = sess.ProjectPath
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+
+	ptmx, err := pty.Start(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("runtime/local: failed to start PTY: %w", err)
+	}
+
+	r.cmd = cmd
+	r.ptmx = ptmx
+
+	// pty.Start forks the child but never reaps it; without a Wait call
+	// here, every claude invocation becomes a zombie once it exits, and
+	// Stop's SIGKILL escalation timer (which only cancels on PTY EOF) never
+	// actually collects it either.
+	go func() {
+		cmd.Wait()
+	}()
+
+	return ptmx, nil
+}
+
+// Pid returns the running child's process ID, or 0 if Start hasn't been
+// called yet. Mainly useful for tests that need to confirm the process
+// was actually reaped, not just that its PTY closed.
+func (r *Runtime) Pid() int {
+	if r.cmd == nil || r.cmd.Process == nil {
+		return 0
+	}
+	return r.cmd.Process.Pid
+}
+
+func (r *Runtime) Signal(sig syscall.Signal) error {
+	if r.cmd == nil || r.cmd.Process == nil {
+		return fmt.Errorf("runtime/local: no running process")
+	}
+	return syscall.Kill(-r.cmd.Process.Pid, sig)
+}
+
+func (r *Runtime) Resize(cols, rows uint16) error {
+	if r.ptmx == nil {
+		return fmt.Errorf("runtime/local: no PTY available")
+	}
+	return pty.Setsize(r.ptmx, &pty.Winsize{Cols: cols, Rows: rows})
+}
+
+func (r *Runtime) Close() error {
+	if r.ptmx == nil {
+		return nil
+	}
+	return r.ptmx.Close()
+}
@@ -0,0 +1,91 @@
+package local
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/user/teleclaude/internal/session"
+)
+
+// TestRuntimeStartAppliesWindowSize starts a fake "claude" (a shell script
+// on PATH) that prints the PTY's column count via tput once it's had time
+// to settle, and checks it reflects the size set by Resize — the same
+// order claude.Controller.Start uses: Start, then Resize. Regresses the
+// chunk1-6 reap fix silently breaking Setsize/SIGWINCH propagation.
+func TestRuntimeStartAppliesWindowSize(t *testing.T) {
+	binDir := t.TempDir()
+	script := filepath.Join(binDir, "claude")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\nsleep 0.2\ntput cols\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	r := New()
+	stream, err := r.Start(context.Background(), &session.Session{ProjectPath: t.TempDir()}, nil)
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer r.Close()
+
+	if err := r.Resize(123, 40); err != nil {
+		t.Fatalf("Resize() error = %v", err)
+	}
+
+	type result struct {
+		cols int
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		scanner := bufio.NewScanner(stream)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" {
+				continue
+			}
+			cols, err := strconv.Atoi(line)
+			done <- result{cols: cols, err: err}
+			return
+		}
+		done <- result{err: scanner.Err()}
+	}()
+
+	select {
+	case res := <-done:
+		if res.err != nil {
+			t.Fatalf("failed to read applied column count: %v", res.err)
+		}
+		if res.cols != 123 {
+			t.Errorf("applied cols = %d, want 123", res.cols)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the child to report its window size")
+	}
+}
+
+func TestRuntimeSignalBeforeStart(t *testing.T) {
+	r := New()
+	if err := r.Signal(0); err == nil {
+		t.Error("expected error signaling before Start")
+	}
+}
+
+func TestRuntimeResizeBeforeStart(t *testing.T) {
+	r := New()
+	if err := r.Resize(80, 24); err == nil {
+		t.Error("expected error resizing before Start")
+	}
+}
+
+func TestRuntimeCloseBeforeStart(t *testing.T) {
+	r := New()
+	if err := r.Close(); err != nil {
+		t.Errorf("expected Close before Start to be a no-op, got %v", err)
+	}
+}
@@ -0,0 +1,36 @@
+// Package runtime abstracts what actually launches and controls the
+// process backing one claude.Controller invocation, so a session can run
+// as a plain host process or inside an isolated container without
+// claude.Controller knowing the difference.
+package runtime
+
+import (
+	"context"
+	"io"
+	"syscall"
+
+	"github.com/user/teleclaude/internal/session"
+)
+
+// Runtime launches and controls the OS-level process or container backing
+// one claude CLI invocation.
+type Runtime interface {
+	// Start launches claude with args for sess, returning a combined
+	// stdin/stdout stream. If sess.ContainerID is already set (from a
+	// prior Start), an implementation should reattach to it instead of
+	// starting a fresh process/container. An implementation that
+	// allocates a backend handle writes it back onto sess.ContainerID so
+	// it survives a bot restart.
+	Start(ctx context.Context, sess *session.Session, args []string) (io.ReadWriter, error)
+
+	// Signal delivers sig to the running process, e.g. SIGTERM/SIGKILL to
+	// stop it or SIGWINCH after Resize.
+	Signal(sig syscall.Signal) error
+
+	// Resize propagates a terminal size change to the running process.
+	Resize(cols, rows uint16) error
+
+	// Close releases the runtime's resources: closing the PTY for
+	// runtime/local, stopping the container for runtime/docker.
+	Close() error
+}
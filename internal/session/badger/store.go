@@ -0,0 +1,309 @@
+// Package badger implements session.SessionStore on top of an embedded
+// BadgerDB database, for deployments that have accumulated enough sessions
+// that the file-based Storage's directory scans in ListAll/ListByUser start
+// to show up as a cost.
+package badger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	badger "github.com/dgraph-io/badger/v4"
+	"github.com/dgraph-io/badger/v4/pb"
+	"github.com/user/teleclaude/internal/session"
+	"github.com/user/teleclaude/internal/transport"
+)
+
+// Key layout:
+//
+//	session/<id>                                -> marshaled session
+//	idx/user/<transport>:<userID>/<id>           -> empty
+//	idx/status/<status>/<id>                     -> empty
+//	idx/active/<last_active_unix_nano>/<id>      -> empty
+//
+// The index entries let ListByUser, "list active", and "last N idle"
+// queries walk a narrow key prefix instead of loading and unmarshaling
+// every session record, the way Storage's directory scan has to.
+const (
+	sessionPrefix   = "session/"
+	userIdxPrefix   = "idx/user/"
+	statusIdxPrefix = "idx/status/"
+	activeIdxPrefix = "idx/active/"
+)
+
+// Store persists sessions in an embedded BadgerDB database at a local
+// directory. Unlike ConsulStore and EtcdStore it isn't shared across bot
+// instances; it's a faster drop-in for the single-instance YAML file store.
+type Store struct {
+	db *badger.DB
+}
+
+// New opens (creating if necessary) a BadgerDB database at dir.
+func New(dir string) (*Store, error) {
+	opts := badger.DefaultOptions(dir)
+	opts.Logger = nil // badger's default logger is noisy at info level
+
+	db, err := badger.Open(opts)
+	if err != nil {
+		return nil, fmt.Errorf("badger: failed to open %s: %w", dir, err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+var _ session.SessionStore = (*Store)(nil)
+
+// Close releases the underlying database file locks.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func sessionKey(id string) []byte {
+	return []byte(sessionPrefix + id)
+}
+
+func userIdxKey(t transport.Type, userID, id string) []byte {
+	return []byte(userIdxPrefix + string(t) + ":" + userID + "/" + id)
+}
+
+func statusIdxKey(status session.Status, id string) []byte {
+	return []byte(statusIdxPrefix + string(status) + "/" + id)
+}
+
+func activeIdxKey(lastActiveUnixNano int64, id string) []byte {
+	return []byte(fmt.Sprintf("%s%020d/%s", activeIdxPrefix, lastActiveUnixNano, id))
+}
+
+func loadTxn(txn *badger.Txn, id string) (*session.Session, error) {
+	item, err := txn.Get(sessionKey(id))
+	if err != nil {
+		return nil, err
+	}
+
+	sess := &session.Session{}
+	err = item.Value(func(val []byte) error {
+		return json.Unmarshal(val, sess)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sess, nil
+}
+
+// deleteIndexes removes the index entries a previously saved copy of sess
+// would have written, so Save never leaves a stale pointer behind when a
+// session's user, status, or last-active time changes.
+func deleteIndexes(txn *badger.Txn, sess *session.Session) error {
+	keys := [][]byte{
+		userIdxKey(sess.Transport, sess.TransportUserID, sess.ID),
+		statusIdxKey(sess.Status, sess.ID),
+		activeIdxKey(sess.LastActive.UnixNano(), sess.ID),
+	}
+	for _, key := range keys {
+		if err := txn.Delete(key); err != nil && err != badger.ErrKeyNotFound {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Store) Save(sess *session.Session) error {
+	data, err := json.Marshal(sess)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(txn *badger.Txn) error {
+		if prev, err := loadTxn(txn, sess.ID); err == nil {
+			if err := deleteIndexes(txn, prev); err != nil {
+				return err
+			}
+		} else if err != badger.ErrKeyNotFound {
+			return err
+		}
+
+		if err := txn.Set(sessionKey(sess.ID), data); err != nil {
+			return err
+		}
+		if err := txn.Set(userIdxKey(sess.Transport, sess.TransportUserID, sess.ID), nil); err != nil {
+			return err
+		}
+		if err := txn.Set(statusIdxKey(sess.Status, sess.ID), nil); err != nil {
+			return err
+		}
+		return txn.Set(activeIdxKey(sess.LastActive.UnixNano(), sess.ID), nil)
+	})
+}
+
+func (s *Store) Load(id string) (*session.Session, error) {
+	var sess *session.Session
+	err := s.db.View(func(txn *badger.Txn) error {
+		got, err := loadTxn(txn, id)
+		if err != nil {
+			return err
+		}
+		sess = got
+		return nil
+	})
+	if err == badger.ErrKeyNotFound {
+		return nil, fmt.Errorf("badger: session %s not found", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return sess, nil
+}
+
+func (s *Store) Delete(id string) error {
+	return s.db.Update(func(txn *badger.Txn) error {
+		prev, err := loadTxn(txn, id)
+		if err != nil {
+			if err == badger.ErrKeyNotFound {
+				return nil
+			}
+			return err
+		}
+		if err := deleteIndexes(txn, prev); err != nil {
+			return err
+		}
+		return txn.Delete(sessionKey(id))
+	})
+}
+
+func (s *Store) ListByUser(t transport.Type, userID string) ([]*session.Session, error) {
+	prefix := []byte(userIdxPrefix + string(t) + ":" + userID + "/")
+
+	var sessions []*session.Session
+	err := s.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			id := strings.TrimPrefix(string(it.Item().Key()), string(prefix))
+			sess, err := loadTxn(txn, id)
+			if err != nil {
+				continue // index pointed at a since-deleted session
+			}
+			sessions = append(sessions, sess)
+		}
+		return nil
+	})
+	return sessions, err
+}
+
+// ListByStatus returns every session in the given status using the status
+// index, without loading sessions in any other status.
+func (s *Store) ListByStatus(status session.Status) ([]*session.Session, error) {
+	prefix := []byte(statusIdxPrefix + string(status) + "/")
+
+	var sessions []*session.Session
+	err := s.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			id := strings.TrimPrefix(string(it.Item().Key()), string(prefix))
+			sess, err := loadTxn(txn, id)
+			if err != nil {
+				continue
+			}
+			sessions = append(sessions, sess)
+		}
+		return nil
+	})
+	return sessions, err
+}
+
+// ListIdle returns up to n idle sessions, oldest-last-active first, using
+// the last-active index so a janitor pass can find GC candidates without
+// loading every session the store holds.
+func (s *Store) ListIdle(n int) ([]*session.Session, error) {
+	var sessions []*session.Session
+	err := s.db.View(func(txn *badger.Txn) error {
+		prefix := []byte(activeIdxPrefix)
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Seek(prefix); it.ValidForPrefix(prefix) && len(sessions) < n; it.Next() {
+			key := strings.TrimPrefix(string(it.Item().Key()), string(prefix))
+			parts := strings.SplitN(key, "/", 2)
+			if len(parts) != 2 {
+				continue
+			}
+			sess, err := loadTxn(txn, parts[1])
+			if err != nil || sess.Status != session.StatusIdle {
+				continue
+			}
+			sessions = append(sessions, sess)
+		}
+		return nil
+	})
+	return sessions, err
+}
+
+func (s *Store) ListAll() ([]*session.Session, error) {
+	var sessions []*session.Session
+	err := s.db.View(func(txn *badger.Txn) error {
+		prefix := []byte(sessionPrefix)
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			sess := &session.Session{}
+			err := it.Item().Value(func(val []byte) error {
+				return json.Unmarshal(val, sess)
+			})
+			if err != nil {
+				continue
+			}
+			sessions = append(sessions, sess)
+		}
+		return nil
+	})
+	return sessions, err
+}
+
+// Watch subscribes to Badger's native change stream on the session/ key
+// prefix, the same role EtcdStore.Watch plays for etcd's watch API. Index
+// key writes aren't included in the subscription match, so only session
+// put/delete events are reported.
+func (s *Store) Watch(ctx context.Context) <-chan session.Event {
+	events := make(chan session.Event, 16)
+
+	go func() {
+		defer close(events)
+
+		matches := []pb.Match{{Prefix: []byte(sessionPrefix)}}
+		err := s.db.Subscribe(ctx, func(kvs *badger.KVList) error {
+			for _, kv := range kvs.GetKv() {
+				id := strings.TrimPrefix(string(kv.GetKey()), sessionPrefix)
+
+				if len(kv.GetValue()) == 0 {
+					events <- session.Event{Type: session.EventDelete, ID: id}
+					continue
+				}
+
+				sess := &session.Session{}
+				if err := json.Unmarshal(kv.GetValue(), sess); err != nil {
+					continue
+				}
+				events <- session.Event{Type: session.EventPut, Session: sess}
+			}
+			return nil
+		}, matches)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("badger: subscribe stopped: %v", err)
+		}
+	}()
+
+	return events
+}
@@ -0,0 +1,115 @@
+package badger
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/user/teleclaude/internal/session"
+	"github.com/user/teleclaude/internal/transport"
+)
+
+func TestStoreSaveAndLoad(t *testing.T) {
+	store, err := New(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer store.Close()
+
+	sess := &session.Session{
+		ID:              "test123",
+		Transport:       transport.TypeTelegram,
+		TransportUserID: "12345678",
+		Status:          session.StatusActive,
+		TotalCostUSD:    1.23,
+	}
+
+	if err := store.Save(sess); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := store.Load("test123")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.TransportUserID != sess.TransportUserID {
+		t.Errorf("TransportUserID = %s, want %s", loaded.TransportUserID, sess.TransportUserID)
+	}
+	if loaded.TotalCostUSD != sess.TotalCostUSD {
+		t.Errorf("TotalCostUSD = %f, want %f", loaded.TotalCostUSD, sess.TotalCostUSD)
+	}
+}
+
+func TestStoreListByUser(t *testing.T) {
+	store, err := New(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer store.Close()
+
+	s1 := &session.Session{ID: "s1", Transport: transport.TypeTelegram, TransportUserID: "111", Status: session.StatusActive}
+	s2 := &session.Session{ID: "s2", Transport: transport.TypeTelegram, TransportUserID: "111", Status: session.StatusIdle}
+	s3 := &session.Session{ID: "s3", Transport: transport.TypeTelegram, TransportUserID: "222", Status: session.StatusActive}
+
+	store.Save(s1)
+	store.Save(s2)
+	store.Save(s3)
+
+	sessions, err := store.ListByUser(transport.TypeTelegram, "111")
+	if err != nil {
+		t.Fatalf("ListByUser() error = %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Errorf("ListByUser(111) returned %d sessions, want 2", len(sessions))
+	}
+}
+
+func TestStoreSaveMovesStaleIndexEntries(t *testing.T) {
+	store, err := New(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer store.Close()
+
+	sess := &session.Session{ID: "s1", Transport: transport.TypeTelegram, TransportUserID: "111", Status: session.StatusActive}
+	store.Save(sess)
+
+	// Re-save under a different user; the old user index entry must not
+	// leak a stale pointer into ListByUser("111").
+	sess.TransportUserID = "222"
+	store.Save(sess)
+
+	oldUser, err := store.ListByUser(transport.TypeTelegram, "111")
+	if err != nil {
+		t.Fatalf("ListByUser(111) error = %v", err)
+	}
+	if len(oldUser) != 0 {
+		t.Errorf("ListByUser(111) returned %d sessions after reassignment, want 0", len(oldUser))
+	}
+
+	newUser, err := store.ListByUser(transport.TypeTelegram, "222")
+	if err != nil {
+		t.Fatalf("ListByUser(222) error = %v", err)
+	}
+	if len(newUser) != 1 {
+		t.Errorf("ListByUser(222) returned %d sessions, want 1", len(newUser))
+	}
+}
+
+func TestStoreDelete(t *testing.T) {
+	store, err := New(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer store.Close()
+
+	sess := &session.Session{ID: "todelete", Transport: transport.TypeTelegram, TransportUserID: "111"}
+	store.Save(sess)
+
+	if err := store.Delete("todelete"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	if _, err := store.Load("todelete"); err == nil {
+		t.Error("Load() should fail after Delete()")
+	}
+}
@@ -0,0 +1,167 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/user/teleclaude/internal/transport"
+	"gopkg.in/yaml.v3"
+)
+
+// ConsulStore persists sessions under prefix/<id> in Consul's KV store so
+// multiple bot instances behind a load balancer can share session state.
+type ConsulStore struct {
+	client *api.Client
+	prefix string
+}
+
+// NewConsulStore dials the Consul agent at addr and returns a store that
+// keys entries under prefix (e.g. "teleclaude/sessions").
+func NewConsulStore(addr, prefix string, tls *TLSConfig) (*ConsulStore, error) {
+	cfg := api.DefaultConfig()
+	cfg.Address = addr
+	if tls != nil && tls.Enabled {
+		cfg.TLSConfig = api.TLSConfig{
+			CAFile:   tls.CAFile,
+			CertFile: tls.CertFile,
+			KeyFile:  tls.KeyFile,
+		}
+	}
+
+	client, err := api.NewClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("consul: failed to create client: %w", err)
+	}
+
+	return &ConsulStore{client: client, prefix: strings.Trim(prefix, "/")}, nil
+}
+
+var _ SessionStore = (*ConsulStore)(nil)
+
+func (c *ConsulStore) key(id string) string {
+	return c.prefix + "/" + id
+}
+
+func (c *ConsulStore) Save(session *Session) error {
+	data, err := yaml.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	kv := c.client.KV()
+	_, err = kv.Put(&api.KVPair{Key: c.key(session.ID), Value: data}, nil)
+	return err
+}
+
+func (c *ConsulStore) Load(id string) (*Session, error) {
+	kv := c.client.KV()
+	pair, _, err := kv.Get(c.key(id), nil)
+	if err != nil {
+		return nil, err
+	}
+	if pair == nil {
+		return nil, fmt.Errorf("consul: session %s not found", id)
+	}
+
+	session := &Session{}
+	if err := yaml.Unmarshal(pair.Value, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func (c *ConsulStore) Delete(id string) error {
+	kv := c.client.KV()
+	_, err := kv.Delete(c.key(id), nil)
+	return err
+}
+
+func (c *ConsulStore) ListByUser(t transport.Type, userID string) ([]*Session, error) {
+	all, err := c.ListAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []*Session
+	for _, s := range all {
+		if s.Transport == t && s.TransportUserID == userID {
+			sessions = append(sessions, s)
+		}
+	}
+	return sessions, nil
+}
+
+func (c *ConsulStore) ListAll() ([]*Session, error) {
+	kv := c.client.KV()
+	pairs, _, err := kv.List(c.prefix+"/", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []*Session
+	for _, pair := range pairs {
+		session := &Session{}
+		if err := yaml.Unmarshal(pair.Value, session); err != nil {
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+// Watch long-polls the Consul KV prefix via blocking queries and translates
+// index changes into Put events. Consul does not report deletes from a
+// prefix listing directly, so a deletion is detected as a key missing from
+// the next listing and reported then as an EventDelete.
+func (c *ConsulStore) Watch(ctx context.Context) <-chan Event {
+	events := make(chan Event, 16)
+
+	go func() {
+		defer close(events)
+
+		kv := c.client.KV()
+		var lastIndex uint64
+		known := make(map[string]bool)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+
+			opts := (&api.QueryOptions{WaitIndex: lastIndex}).WithContext(ctx)
+			pairs, meta, err := kv.List(c.prefix+"/", opts)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				continue
+			}
+			lastIndex = meta.LastIndex
+
+			seen := make(map[string]bool, len(pairs))
+			for _, pair := range pairs {
+				id := strings.TrimPrefix(pair.Key, c.prefix+"/")
+				seen[id] = true
+
+				session := &Session{}
+				if err := yaml.Unmarshal(pair.Value, session); err != nil {
+					continue
+				}
+				events <- Event{Type: EventPut, Session: session}
+			}
+
+			for id := range known {
+				if !seen[id] {
+					events <- Event{Type: EventDelete, ID: id}
+				}
+			}
+			known = seen
+		}
+	}()
+
+	return events
+}
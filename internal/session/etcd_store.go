@@ -0,0 +1,155 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/user/teleclaude/internal/transport"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// EtcdStore persists sessions under prefix/<id> in etcd, giving the bot a
+// shared session store across replicas without relying on a shared
+// filesystem.
+type EtcdStore struct {
+	client *clientv3.Client
+	prefix string
+}
+
+// NewEtcdStore dials the given etcd endpoints and returns a store that keys
+// entries under prefix (e.g. "teleclaude/sessions").
+func NewEtcdStore(endpoints []string, prefix string, tls *TLSConfig) (*EtcdStore, error) {
+	cfg := clientv3.Config{
+		Endpoints:   endpoints,
+		DialTimeout: 5 * time.Second,
+	}
+
+	if tls != nil && tls.Enabled {
+		tlsConfig, err := BuildTLSConfig(tls)
+		if err != nil {
+			return nil, fmt.Errorf("etcd: failed to build TLS config: %w", err)
+		}
+		cfg.TLS = tlsConfig
+	}
+
+	client, err := clientv3.New(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("etcd: failed to create client: %w", err)
+	}
+
+	return &EtcdStore{client: client, prefix: strings.Trim(prefix, "/")}, nil
+}
+
+var _ SessionStore = (*EtcdStore)(nil)
+
+func (e *EtcdStore) key(id string) string {
+	return e.prefix + "/" + id
+}
+
+func (e *EtcdStore) Save(session *Session) error {
+	data, err := yaml.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err = e.client.Put(ctx, e.key(session.ID), string(data))
+	return err
+}
+
+func (e *EtcdStore) Load(id string) (*Session, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := e.client.Get(ctx, e.key(id))
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, fmt.Errorf("etcd: session %s not found", id)
+	}
+
+	session := &Session{}
+	if err := yaml.Unmarshal(resp.Kvs[0].Value, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func (e *EtcdStore) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := e.client.Delete(ctx, e.key(id))
+	return err
+}
+
+func (e *EtcdStore) ListByUser(t transport.Type, userID string) ([]*Session, error) {
+	all, err := e.ListAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []*Session
+	for _, s := range all {
+		if s.Transport == t && s.TransportUserID == userID {
+			sessions = append(sessions, s)
+		}
+	}
+	return sessions, nil
+}
+
+func (e *EtcdStore) ListAll() ([]*Session, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := e.client.Get(ctx, e.prefix+"/", clientv3.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []*Session
+	for _, kv := range resp.Kvs {
+		session := &Session{}
+		if err := yaml.Unmarshal(kv.Value, session); err != nil {
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+// Watch subscribes to etcd's native watch stream on the prefix and
+// translates PUT/DELETE events directly, unlike the Consul backend which
+// has to diff successive listings.
+func (e *EtcdStore) Watch(ctx context.Context) <-chan Event {
+	events := make(chan Event, 16)
+	watchCh := e.client.Watch(ctx, e.prefix+"/", clientv3.WithPrefix())
+
+	go func() {
+		defer close(events)
+
+		for resp := range watchCh {
+			for _, change := range resp.Events {
+				switch change.Type {
+				case clientv3.EventTypePut:
+					session := &Session{}
+					if err := yaml.Unmarshal(change.Kv.Value, session); err != nil {
+						continue
+					}
+					events <- Event{Type: EventPut, Session: session}
+				case clientv3.EventTypeDelete:
+					id := strings.TrimPrefix(string(change.Kv.Key), e.prefix+"/")
+					events <- Event{Type: EventDelete, ID: id}
+				}
+			}
+		}
+	}()
+
+	return events
+}
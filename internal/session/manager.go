@@ -1,68 +1,237 @@
 package session
 
 import (
+	"context"
 	"errors"
 	"sync"
+	"time"
+
+	"github.com/user/teleclaude/internal/transport"
 )
 
 var ErrSessionNotFound = errors.New("session not found")
 
 type Manager struct {
-	storage        *Storage
-	activeSessions map[int64]*Session // userID -> active session
+	storage        SessionStore
+	activeSessions map[string]*Session // userKey(transport, userID) -> active session
 	mu             sync.RWMutex
+
+	defaultTTL           time.Duration
+	defaultRenewInterval time.Duration
+
+	onIdle func(sessionID string)
+}
+
+// SetOnIdle registers a callback fired whenever a session transitions to
+// StatusIdle, e.g. so a runtime.Runtime backing a container-per-session
+// sandbox can be torn down once nothing is actively using it. Call once at
+// startup; nil (the default) disables the hook.
+func (m *Manager) SetOnIdle(fn func(sessionID string)) {
+	m.onIdle = fn
 }
 
-func NewManager(storage *Storage) *Manager {
+// userKey composites a transport and its native user ID into the single
+// string activeSessions is keyed by, since a transport user ID is only
+// unique within its own network.
+func userKey(t transport.Type, userID string) string {
+	return string(t) + ":" + userID
+}
+
+func NewManager(storage SessionStore) *Manager {
 	return &Manager{
 		storage:        storage,
-		activeSessions: make(map[int64]*Session),
+		activeSessions: make(map[string]*Session),
 	}
 }
 
-func (m *Manager) GetActiveSession(userID int64) *Session {
+// SetSessionDefaults configures the TTL and renew interval newly created
+// sessions are stamped with. Call once at startup from the sessions:
+// config block; a zero ttl means sessions never expire.
+func (m *Manager) SetSessionDefaults(ttl, renewInterval time.Duration) {
+	m.defaultTTL = ttl
+	m.defaultRenewInterval = renewInterval
+}
+
+// WatchForUpdates subscribes to the underlying store's Watch stream and
+// keeps the in-memory active-session map in sync with changes made by other
+// nodes sharing the same backend. It returns immediately; the subscription
+// runs until ctx is cancelled.
+func (m *Manager) WatchForUpdates(ctx context.Context) {
+	go func() {
+		for event := range m.storage.Watch(ctx) {
+			switch event.Type {
+			case EventPut:
+				m.applyRemoteUpdate(event.Session)
+			case EventDelete:
+				m.applyRemoteDelete(event.ID)
+			}
+		}
+	}()
+}
+
+func (m *Manager) applyRemoteUpdate(updated *Session) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := userKey(updated.Transport, updated.TransportUserID)
+	if active, ok := m.activeSessions[key]; ok && active.ID == updated.ID {
+		m.activeSessions[key] = updated
+	}
+}
+
+func (m *Manager) applyRemoteDelete(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, session := range m.activeSessions {
+		if session.ID == id {
+			delete(m.activeSessions, key)
+		}
+	}
+}
+
+func (m *Manager) GetActiveSession(t transport.Type, userID string) *Session {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	return m.activeSessions[userID]
+	return m.activeSessions[userKey(t, userID)]
 }
 
-func (m *Manager) SetActiveSession(userID int64, session *Session) {
+func (m *Manager) SetActiveSession(t transport.Type, userID string, session *Session) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	key := userKey(t, userID)
+
 	// Mark previous session as idle
-	if prev, exists := m.activeSessions[userID]; exists && prev.ID != session.ID {
+	if prev, exists := m.activeSessions[key]; exists && prev.ID != session.ID {
 		prev.MarkIdle()
 		m.storage.Save(prev)
+		if m.onIdle != nil {
+			m.onIdle(prev.ID)
+		}
 	}
 
 	session.MarkActive()
-	m.activeSessions[userID] = session
+	m.activeSessions[key] = session
 	m.storage.Save(session)
 }
 
-func (m *Manager) CreateSession(userID int64, projectPath, projectName string) (*Session, error) {
-	session := New("", userID, projectPath, projectName)
+func (m *Manager) CreateSession(t transport.Type, userID string, projectPath, projectName string) (*Session, error) {
+	session := New("", t, userID, projectPath, projectName)
+	session.TTL = m.defaultTTL
+	session.RenewInterval = m.defaultRenewInterval
 
 	if err := m.storage.Save(session); err != nil {
 		return nil, err
 	}
 
-	m.SetActiveSession(userID, session)
+	m.SetActiveSession(t, userID, session)
 	return session, nil
 }
 
-func (m *Manager) SwitchSession(userID int64, sessionID string) error {
+// RenewSession updates a session's heartbeat (LastActive + Generation),
+// keeping it from expiring while it's actively being driven. Called from
+// the message-handling path on every inbound message.
+func (m *Manager) RenewSession(sessionID string) error {
+	session, err := m.storage.Load(sessionID)
+	if err != nil {
+		return err
+	}
+
+	session.Heartbeat()
+	if err := m.storage.Save(session); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	for _, active := range m.activeSessions {
+		if active.ID == sessionID {
+			active.Heartbeat()
+			break
+		}
+	}
+	m.mu.Unlock()
+
+	return nil
+}
+
+// StartJanitor runs the TTL/heartbeat/retention loop every tickInterval
+// until ctx is cancelled. isRunning reports whether a session's controller
+// is still active; running sessions get a heartbeat renewal instead of
+// being allowed to expire out from under a live controller.
+func (m *Manager) StartJanitor(ctx context.Context, tickInterval, retention time.Duration, isRunning func(sessionID string) bool) {
+	go func() {
+		ticker := time.NewTicker(tickInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.runJanitorPass(retention, isRunning)
+			}
+		}
+	}()
+}
+
+// GC runs one janitor pass immediately, expiring stale sessions and
+// deleting ones past their retention window. It returns the number of
+// sessions newly marked expired. Exposed for the /gc admin command.
+func (m *Manager) GC(retention time.Duration, isRunning func(sessionID string) bool) (int, error) {
+	return m.runJanitorPass(retention, isRunning)
+}
+
+func (m *Manager) runJanitorPass(retention time.Duration, isRunning func(sessionID string) bool) (int, error) {
+	all, err := m.storage.ListAll()
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	expired := 0
+
+	for _, s := range all {
+		switch {
+		case s.Status == StatusExpired:
+			if retention > 0 && now.Sub(s.LastActive) > retention {
+				m.storage.Delete(s.ID)
+			}
+
+		case isRunning != nil && isRunning(s.ID):
+			s.Heartbeat()
+			m.storage.Save(s)
+
+		case s.Expired(now):
+			s.Status = StatusExpired
+			s.LastActive = now
+			m.storage.Save(s)
+			expired++
+
+			m.mu.Lock()
+			for key, active := range m.activeSessions {
+				if active.ID == s.ID {
+					delete(m.activeSessions, key)
+				}
+			}
+			m.mu.Unlock()
+		}
+	}
+
+	return expired, nil
+}
+
+func (m *Manager) SwitchSession(t transport.Type, userID string, sessionID string) error {
 	session, err := m.storage.Load(sessionID)
 	if err != nil {
 		return ErrSessionNotFound
 	}
 
-	if session.TelegramUser != userID {
+	if session.Transport != t || session.TransportUserID != userID {
 		return ErrSessionNotFound // Don't expose other users' sessions
 	}
 
-	m.SetActiveSession(userID, session)
+	m.SetActiveSession(t, userID, session)
 	return nil
 }
 
@@ -97,8 +266,122 @@ func (m *Manager) AddCost(sessionID string, cost float64) error {
 	return m.storage.Save(session)
 }
 
-func (m *Manager) GetUserSessions(userID int64) ([]*Session, error) {
-	return m.storage.ListByUser(userID)
+// SetVoicePreference updates whether approval prompts on a session are also
+// delivered as a synthesized voice note, persisting the change and keeping
+// the in-memory copy (if the session is currently active) in sync.
+func (m *Manager) SetVoicePreference(sessionID string, enabled bool) error {
+	session, err := m.storage.Load(sessionID)
+	if err != nil {
+		return err
+	}
+
+	session.VoiceEnabled = enabled
+
+	m.mu.Lock()
+	for _, active := range m.activeSessions {
+		if active.ID == sessionID {
+			active.VoiceEnabled = enabled
+			break
+		}
+	}
+	m.mu.Unlock()
+
+	return m.storage.Save(session)
+}
+
+// SetRole stamps a session with its owning user's current config.UserEntry
+// role ("admin", "operator", or "viewer"), called right after creation so
+// approval.Engine.Evaluate can see it without looking the user back up
+// through config on every tool-use message.
+func (m *Manager) SetRole(sessionID, role string) error {
+	session, err := m.storage.Load(sessionID)
+	if err != nil {
+		return err
+	}
+
+	session.Role = role
+
+	m.mu.Lock()
+	for _, active := range m.activeSessions {
+		if active.ID == sessionID {
+			active.Role = role
+			break
+		}
+	}
+	m.mu.Unlock()
+
+	return m.storage.Save(session)
+}
+
+// AddApprovalOverride adds or replaces (by name) a per-session approval
+// override, e.g. "for this session only, auto-allow go test", persisting
+// the change and keeping the in-memory copy in sync.
+func (m *Manager) AddApprovalOverride(sessionID string, override ApprovalOverride) error {
+	session, err := m.storage.Load(sessionID)
+	if err != nil {
+		return err
+	}
+
+	replaced := false
+	for i, existing := range session.ApprovalOverrides {
+		if existing.Name == override.Name {
+			session.ApprovalOverrides[i] = override
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		session.ApprovalOverrides = append(session.ApprovalOverrides, override)
+	}
+
+	m.mu.Lock()
+	for _, active := range m.activeSessions {
+		if active.ID == sessionID {
+			active.ApprovalOverrides = session.ApprovalOverrides
+			break
+		}
+	}
+	m.mu.Unlock()
+
+	return m.storage.Save(session)
+}
+
+// RemoveApprovalOverride removes a named override from a session. Reports
+// whether an override with that name existed.
+func (m *Manager) RemoveApprovalOverride(sessionID, name string) (bool, error) {
+	session, err := m.storage.Load(sessionID)
+	if err != nil {
+		return false, err
+	}
+
+	removed := false
+	kept := session.ApprovalOverrides[:0]
+	for _, existing := range session.ApprovalOverrides {
+		if existing.Name == name {
+			removed = true
+			continue
+		}
+		kept = append(kept, existing)
+	}
+	session.ApprovalOverrides = kept
+	if !removed {
+		return false, nil
+	}
+
+	m.mu.Lock()
+	for _, active := range m.activeSessions {
+		if active.ID == sessionID {
+			active.ApprovalOverrides = session.ApprovalOverrides
+			break
+		}
+	}
+	m.mu.Unlock()
+
+	return true, m.storage.Save(session)
+}
+
+func (m *Manager) GetUserSessions(t transport.Type, userID string) ([]*Session, error) {
+	return m.storage.ListByUser(t, userID)
 }
 
 func (m *Manager) GetSession(sessionID string) (*Session, error) {
@@ -109,10 +392,13 @@ func (m *Manager) MarkAllIdle() error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	for userID, session := range m.activeSessions {
+	for key, session := range m.activeSessions {
 		session.MarkIdle()
 		m.storage.Save(session)
-		delete(m.activeSessions, userID)
+		delete(m.activeSessions, key)
+		if m.onIdle != nil {
+			m.onIdle(session.ID)
+		}
 	}
 
 	return nil
@@ -2,6 +2,9 @@ package session
 
 import (
 	"testing"
+	"time"
+
+	"github.com/user/teleclaude/internal/transport"
 )
 
 func TestManagerGetActiveSession(t *testing.T) {
@@ -10,16 +13,16 @@ func TestManagerGetActiveSession(t *testing.T) {
 	manager := NewManager(storage)
 
 	// No active session initially
-	if s := manager.GetActiveSession(12345); s != nil {
+	if s := manager.GetActiveSession(transport.TypeTelegram, "12345"); s != nil {
 		t.Error("GetActiveSession() should return nil when no session exists")
 	}
 
 	// Create and set active session
-	session := New("claude123", 12345, "/home/user/app", "app")
-	manager.SetActiveSession(12345, session)
+	session := New("claude123", transport.TypeTelegram, "12345", "/home/user/app", "app")
+	manager.SetActiveSession(transport.TypeTelegram, "12345", session)
 
 	// Now should return the session
-	active := manager.GetActiveSession(12345)
+	active := manager.GetActiveSession(transport.TypeTelegram, "12345")
 	if active == nil {
 		t.Fatal("GetActiveSession() returned nil after SetActiveSession()")
 	}
@@ -33,20 +36,20 @@ func TestManagerCreateSession(t *testing.T) {
 	storage := NewStorage(tmpDir)
 	manager := NewManager(storage)
 
-	session, err := manager.CreateSession(12345, "/home/user/app", "app")
+	session, err := manager.CreateSession(transport.TypeTelegram, "12345", "/home/user/app", "app")
 	if err != nil {
 		t.Fatalf("CreateSession() error = %v", err)
 	}
 
-	if session.TelegramUser != 12345 {
-		t.Errorf("TelegramUser = %d, want 12345", session.TelegramUser)
+	if session.TransportUserID != "12345" {
+		t.Errorf("TransportUserID = %s, want 12345", session.TransportUserID)
 	}
 	if session.ProjectPath != "/home/user/app" {
 		t.Errorf("ProjectPath = %s, want /home/user/app", session.ProjectPath)
 	}
 
 	// Should be set as active
-	active := manager.GetActiveSession(12345)
+	active := manager.GetActiveSession(transport.TypeTelegram, "12345")
 	if active == nil || active.ID != session.ID {
 		t.Error("CreateSession() should set the new session as active")
 	}
@@ -67,20 +70,20 @@ func TestManagerSwitchSession(t *testing.T) {
 	manager := NewManager(storage)
 
 	// Create two sessions
-	s1, _ := manager.CreateSession(12345, "/app1", "app1")
-	s2, _ := manager.CreateSession(12345, "/app2", "app2")
+	s1, _ := manager.CreateSession(transport.TypeTelegram, "12345", "/app1", "app1")
+	s2, _ := manager.CreateSession(transport.TypeTelegram, "12345", "/app2", "app2")
 
 	// s2 should be active (most recent)
-	if active := manager.GetActiveSession(12345); active.ID != s2.ID {
+	if active := manager.GetActiveSession(transport.TypeTelegram, "12345"); active.ID != s2.ID {
 		t.Error("Most recent session should be active")
 	}
 
 	// Switch to s1
-	if err := manager.SwitchSession(12345, s1.ID); err != nil {
+	if err := manager.SwitchSession(transport.TypeTelegram, "12345", s1.ID); err != nil {
 		t.Fatalf("SwitchSession() error = %v", err)
 	}
 
-	if active := manager.GetActiveSession(12345); active.ID != s1.ID {
+	if active := manager.GetActiveSession(transport.TypeTelegram, "12345"); active.ID != s1.ID {
 		t.Error("SwitchSession() did not change active session")
 	}
 }
@@ -90,7 +93,7 @@ func TestManagerUpdateClaudeSessionID(t *testing.T) {
 	storage := NewStorage(tmpDir)
 	manager := NewManager(storage)
 
-	session, _ := manager.CreateSession(12345, "/app", "app")
+	session, _ := manager.CreateSession(transport.TypeTelegram, "12345", "/app", "app")
 
 	if err := manager.UpdateClaudeSessionID(session.ID, "claude-xyz"); err != nil {
 		t.Fatalf("UpdateClaudeSessionID() error = %v", err)
@@ -102,3 +105,54 @@ func TestManagerUpdateClaudeSessionID(t *testing.T) {
 		t.Errorf("ClaudeSessionID = %s, want claude-xyz", loaded.ClaudeSessionID)
 	}
 }
+
+func TestManagerRenewSession(t *testing.T) {
+	tmpDir := t.TempDir()
+	storage := NewStorage(tmpDir)
+	manager := NewManager(storage)
+
+	session, _ := manager.CreateSession(transport.TypeTelegram, "12345", "/app", "app")
+	session.LastActive = time.Now().Add(-time.Hour)
+	storage.Save(session)
+
+	if err := manager.RenewSession(session.ID); err != nil {
+		t.Fatalf("RenewSession() error = %v", err)
+	}
+
+	loaded, _ := storage.Load(session.ID)
+	if time.Since(loaded.LastActive) > time.Second {
+		t.Error("RenewSession() did not update LastActive")
+	}
+}
+
+func TestManagerGC(t *testing.T) {
+	tmpDir := t.TempDir()
+	storage := NewStorage(tmpDir)
+	manager := NewManager(storage)
+	manager.SetSessionDefaults(time.Hour, time.Minute)
+
+	running, _ := manager.CreateSession(transport.TypeTelegram, "12345", "/running", "running")
+	idle, _ := manager.CreateSession(transport.TypeTelegram, "12345", "/idle", "idle")
+	idle.LastActive = time.Now().Add(-2 * time.Hour)
+	storage.Save(idle)
+
+	isRunning := func(sessionID string) bool { return sessionID == running.ID }
+
+	expired, err := manager.GC(24*time.Hour, isRunning)
+	if err != nil {
+		t.Fatalf("GC() error = %v", err)
+	}
+	if expired != 1 {
+		t.Errorf("GC() expired = %d, want 1", expired)
+	}
+
+	loadedRunning, _ := storage.Load(running.ID)
+	if loadedRunning.Status == StatusExpired {
+		t.Error("GC() expired a running session")
+	}
+
+	loadedIdle, _ := storage.Load(idle.ID)
+	if loadedIdle.Status != StatusExpired {
+		t.Error("GC() did not expire the idle session past its TTL")
+	}
+}
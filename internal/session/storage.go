@@ -1,13 +1,21 @@
 package session
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/user/teleclaude/internal/transport"
 	"gopkg.in/yaml.v3"
 )
 
+// pollInterval controls how often Storage.Watch rescans the sessions
+// directory for changes made by another process (e.g. another bot instance
+// sharing the same data directory over NFS).
+const pollInterval = 2 * time.Second
+
 type Storage struct {
 	baseDir string
 }
@@ -16,6 +24,8 @@ func NewStorage(baseDir string) *Storage {
 	return &Storage{baseDir: baseDir}
 }
 
+var _ SessionStore = (*Storage)(nil)
+
 func (s *Storage) sessionsDir() string {
 	return filepath.Join(s.baseDir, "sessions")
 }
@@ -55,7 +65,7 @@ func (s *Storage) Delete(id string) error {
 	return os.Remove(s.sessionPath(id))
 }
 
-func (s *Storage) ListByUser(userID int64) ([]*Session, error) {
+func (s *Storage) ListByUser(t transport.Type, userID string) ([]*Session, error) {
 	entries, err := os.ReadDir(s.sessionsDir())
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -76,7 +86,7 @@ func (s *Storage) ListByUser(userID int64) ([]*Session, error) {
 			continue // Skip corrupted files
 		}
 
-		if session.TelegramUser == userID {
+		if session.Transport == t && session.TransportUserID == userID {
 			sessions = append(sessions, session)
 		}
 	}
@@ -109,3 +119,72 @@ func (s *Storage) ListAll() ([]*Session, error) {
 
 	return sessions, nil
 }
+
+// Watch polls the sessions directory for files that changed since the last
+// scan and reports them as Put events. File deletion is reported as a
+// Delete event keyed by session ID. There is no OS-level file watch here
+// deliberately: sessions can be edited or removed by another bot instance
+// sharing the directory over a network filesystem, so mtime polling is the
+// only portable signal.
+func (s *Storage) Watch(ctx context.Context) <-chan Event {
+	events := make(chan Event, 16)
+
+	go func() {
+		defer close(events)
+
+		seen := make(map[string]time.Time)
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.scanOnce(seen, events)
+			}
+		}
+	}()
+
+	return events
+}
+
+func (s *Storage) scanOnce(seen map[string]time.Time, events chan<- Event) {
+	entries, err := os.ReadDir(s.sessionsDir())
+	if err != nil {
+		return
+	}
+
+	current := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".yaml") {
+			continue
+		}
+
+		id := strings.TrimSuffix(entry.Name(), ".yaml")
+		current[id] = true
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		if last, ok := seen[id]; ok && !info.ModTime().After(last) {
+			continue
+		}
+		seen[id] = info.ModTime()
+
+		session, err := s.Load(id)
+		if err != nil {
+			continue
+		}
+		events <- Event{Type: EventPut, Session: session}
+	}
+
+	for id := range seen {
+		if !current[id] {
+			delete(seen, id)
+			events <- Event{Type: EventDelete, ID: id}
+		}
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"testing"
 	"time"
+
+	"github.com/user/teleclaude/internal/transport"
 )
 
 func TestStorageSaveAndLoad(t *testing.T) {
@@ -14,7 +16,8 @@ func TestStorageSaveAndLoad(t *testing.T) {
 	session := &Session{
 		ID:              "test123",
 		ClaudeSessionID: "claude456",
-		TelegramUser:    12345678,
+		Transport:       transport.TypeTelegram,
+		TransportUserID: "12345678",
 		ProjectPath:     "/home/user/myapp",
 		ProjectName:     "myapp",
 		CreatedAt:       time.Now(),
@@ -43,8 +46,8 @@ func TestStorageSaveAndLoad(t *testing.T) {
 	if loaded.ID != session.ID {
 		t.Errorf("ID = %s, want %s", loaded.ID, session.ID)
 	}
-	if loaded.TelegramUser != session.TelegramUser {
-		t.Errorf("TelegramUser = %d, want %d", loaded.TelegramUser, session.TelegramUser)
+	if loaded.TransportUserID != session.TransportUserID {
+		t.Errorf("TransportUserID = %s, want %s", loaded.TransportUserID, session.TransportUserID)
 	}
 	if loaded.TotalCostUSD != session.TotalCostUSD {
 		t.Errorf("TotalCostUSD = %f, want %f", loaded.TotalCostUSD, session.TotalCostUSD)
@@ -56,16 +59,16 @@ func TestStorageListByUser(t *testing.T) {
 	storage := NewStorage(tmpDir)
 
 	// Create sessions for two users
-	s1 := &Session{ID: "s1", TelegramUser: 111, Status: StatusActive}
-	s2 := &Session{ID: "s2", TelegramUser: 111, Status: StatusIdle}
-	s3 := &Session{ID: "s3", TelegramUser: 222, Status: StatusActive}
+	s1 := &Session{ID: "s1", Transport: transport.TypeTelegram, TransportUserID: "111", Status: StatusActive}
+	s2 := &Session{ID: "s2", Transport: transport.TypeTelegram, TransportUserID: "111", Status: StatusIdle}
+	s3 := &Session{ID: "s3", Transport: transport.TypeTelegram, TransportUserID: "222", Status: StatusActive}
 
 	storage.Save(s1)
 	storage.Save(s2)
 	storage.Save(s3)
 
 	// List user 111's sessions
-	sessions, err := storage.ListByUser(111)
+	sessions, err := storage.ListByUser(transport.TypeTelegram, "111")
 	if err != nil {
 		t.Fatalf("ListByUser() error = %v", err)
 	}
@@ -79,7 +82,7 @@ func TestStorageDelete(t *testing.T) {
 	tmpDir := t.TempDir()
 	storage := NewStorage(tmpDir)
 
-	session := &Session{ID: "todelete", TelegramUser: 111}
+	session := &Session{ID: "todelete", Transport: transport.TypeTelegram, TransportUserID: "111"}
 	storage.Save(session)
 
 	if err := storage.Delete("todelete"); err != nil {
@@ -0,0 +1,40 @@
+package session
+
+import (
+	"context"
+
+	"github.com/user/teleclaude/internal/transport"
+)
+
+// EventType identifies the kind of change a SessionStore reported via Watch.
+type EventType string
+
+const (
+	EventPut    EventType = "put"
+	EventDelete EventType = "delete"
+)
+
+// Event is emitted on a SessionStore's Watch channel when a session changes,
+// whether the change originated locally or on another node sharing the
+// backend.
+type Event struct {
+	Type    EventType
+	Session *Session
+	ID      string // set on EventDelete, since the session body is gone
+}
+
+// SessionStore persists sessions and notifies subscribers of changes. The
+// file-based Storage is the default implementation; Consul and etcd backed
+// stores let multiple bot instances share session state.
+type SessionStore interface {
+	Save(session *Session) error
+	Load(id string) (*Session, error)
+	Delete(id string) error
+	ListByUser(t transport.Type, userID string) ([]*Session, error)
+	ListAll() ([]*Session, error)
+
+	// Watch streams Put/Delete events for every session change, including
+	// ones made by other processes against the same backend. The channel is
+	// closed when ctx is done.
+	Watch(ctx context.Context) <-chan Event
+}
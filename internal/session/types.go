@@ -3,7 +3,10 @@ package session
 import (
 	"crypto/rand"
 	"encoding/hex"
+	"strings"
 	"time"
+
+	"github.com/user/teleclaude/internal/transport"
 )
 
 type Status string
@@ -12,26 +15,92 @@ const (
 	StatusActive   Status = "active"
 	StatusIdle     Status = "idle"
 	StatusArchived Status = "archived"
+	StatusExpired  Status = "expired"
 )
 
 type Session struct {
-	ID              string    `yaml:"session_id"`
-	ClaudeSessionID string    `yaml:"claude_session_id"`
-	TelegramUser    int64     `yaml:"telegram_user"`
-	ProjectPath     string    `yaml:"project_path"`
-	ProjectName     string    `yaml:"project_name"`
-	CreatedAt       time.Time `yaml:"created_at"`
-	LastActive      time.Time `yaml:"last_active"`
-	TotalCostUSD    float64   `yaml:"total_cost_usd"`
-	Status          Status    `yaml:"status"`
+	ID              string          `yaml:"session_id"`
+	ClaudeSessionID string          `yaml:"claude_session_id"`
+	Transport       transport.Type  `yaml:"transport"`
+	TransportUserID string          `yaml:"transport_user_id"`
+	ProjectPath     string          `yaml:"project_path"`
+	ProjectName     string          `yaml:"project_name"`
+	CreatedAt       time.Time       `yaml:"created_at"`
+	LastActive      time.Time       `yaml:"last_active"`
+	TotalCostUSD    float64         `yaml:"total_cost_usd"`
+	Status          Status          `yaml:"status"`
+	TTL             time.Duration   `yaml:"ttl"`
+	RenewInterval   time.Duration   `yaml:"renew_interval"`
+	Generation      int             `yaml:"generation"`
+
+	// VoiceEnabled opts this session into hearing approval prompts as a
+	// synthesized voice note in addition to the text message. Off by
+	// default; toggle with /voice on|off.
+	VoiceEnabled bool `yaml:"voice_enabled"`
+
+	// ApprovalOverrides are per-session exceptions to the global
+	// approval.Engine rule set, set via /policy allow|deny. They're checked
+	// before the global rules, so a session can auto-allow or auto-deny a
+	// tool without editing config and restarting the bot.
+	ApprovalOverrides []ApprovalOverride `yaml:"approval_overrides"`
+
+	// ContainerID is the runtime/docker backend's handle for this
+	// session's sandbox container, if runtime.backend is "docker". Empty
+	// when running as a plain host process (runtime.backend "local") or
+	// before the sandbox has been created. Persisted so a bot restart can
+	// reattach to the running container instead of creating a new one.
+	ContainerID string `yaml:"container_id"`
+
+	// NetworkMode is the sandbox container's docker --network value
+	// (e.g. "none" or "bridge"), set by runtime/docker alongside
+	// ContainerID. Empty when not sandboxed. approval.Match.Network reads
+	// this to let a rule auto-allow a command that's only dangerous with
+	// network access, e.g. one confined to a --network=none container.
+	NetworkMode string `yaml:"network_mode"`
+
+	// Role is the owning user's config.UserEntry.Role ("admin", "operator",
+	// or "viewer"), copied onto the session when it's created so
+	// approval.Engine.Evaluate can grant an admin's bypass without needing
+	// to look the user back up through config. A plain string, not
+	// approval.Role, since session cannot import approval (approval
+	// already imports session).
+	Role string `yaml:"role"`
+}
+
+// ApprovalOverride is a session-scoped exception to the global approval
+// rules: auto-allow or auto-deny a tool, optionally only when its command
+// starts with a given prefix. Plain string fields rather than regex, since
+// these are meant to be quick ad hoc overrides rather than a full rule
+// DSL — that lives in approval.Rule.
+type ApprovalOverride struct {
+	Name             string `yaml:"name"`
+	Tool             string `yaml:"tool"`
+	CommandHasPrefix string `yaml:"command_has_prefix"`
+	Action           string `yaml:"action"` // "auto_allow" or "auto_deny"
 }
 
-func New(claudeSessionID string, telegramUser int64, projectPath, projectName string) *Session {
+// FindApprovalOverride returns the first override matching tool and
+// command, if any.
+func (s *Session) FindApprovalOverride(tool, command string) (ApprovalOverride, bool) {
+	for _, o := range s.ApprovalOverrides {
+		if !strings.EqualFold(o.Tool, tool) {
+			continue
+		}
+		if o.CommandHasPrefix != "" && !strings.HasPrefix(command, o.CommandHasPrefix) {
+			continue
+		}
+		return o, true
+	}
+	return ApprovalOverride{}, false
+}
+
+func New(claudeSessionID string, t transport.Type, transportUserID string, projectPath, projectName string) *Session {
 	now := time.Now()
 	return &Session{
 		ID:              generateID(),
 		ClaudeSessionID: claudeSessionID,
-		TelegramUser:    telegramUser,
+		Transport:       t,
+		TransportUserID: transportUserID,
 		ProjectPath:     projectPath,
 		ProjectName:     projectName,
 		CreatedAt:       now,
@@ -41,6 +110,23 @@ func New(claudeSessionID string, telegramUser int64, projectPath, projectName st
 	}
 }
 
+// Heartbeat renews a session's LastActive timestamp and bumps its
+// Generation counter, the same self-healing signal a Consul session lease
+// would send.
+func (s *Session) Heartbeat() {
+	s.LastActive = time.Now()
+	s.Generation++
+}
+
+// Expired reports whether the session's TTL has elapsed since LastActive.
+// A zero TTL means the session never expires.
+func (s *Session) Expired(now time.Time) bool {
+	if s.TTL <= 0 {
+		return false
+	}
+	return now.After(s.LastActive.Add(s.TTL))
+}
+
 func (s *Session) IsActive() bool {
 	return s.Status == StatusActive
 }
@@ -3,6 +3,8 @@ package session
 import (
 	"testing"
 	"time"
+
+	"github.com/user/teleclaude/internal/transport"
 )
 
 func TestSessionStatus(t *testing.T) {
@@ -32,7 +34,7 @@ func TestSessionIsActive(t *testing.T) {
 }
 
 func TestNewSession(t *testing.T) {
-	s := New("abc123", 12345678, "/home/user/myapp", "myapp")
+	s := New("abc123", transport.TypeTelegram, "12345678", "/home/user/myapp", "myapp")
 
 	if s.ID == "" {
 		t.Error("ID should not be empty")
@@ -40,8 +42,8 @@ func TestNewSession(t *testing.T) {
 	if s.ClaudeSessionID != "abc123" {
 		t.Errorf("ClaudeSessionID = %s, want abc123", s.ClaudeSessionID)
 	}
-	if s.TelegramUser != 12345678 {
-		t.Errorf("TelegramUser = %d, want 12345678", s.TelegramUser)
+	if s.TransportUserID != "12345678" {
+		t.Errorf("TransportUserID = %s, want 12345678", s.TransportUserID)
 	}
 	if s.ProjectPath != "/home/user/myapp" {
 		t.Errorf("ProjectPath = %s, want /home/user/myapp", s.ProjectPath)
@@ -56,3 +58,35 @@ func TestNewSession(t *testing.T) {
 		t.Error("CreatedAt should be recent")
 	}
 }
+
+func TestSessionHeartbeat(t *testing.T) {
+	s := &Session{LastActive: time.Now().Add(-time.Hour), Generation: 3}
+
+	s.Heartbeat()
+
+	if time.Since(s.LastActive) > time.Second {
+		t.Error("Heartbeat() should update LastActive to now")
+	}
+	if s.Generation != 4 {
+		t.Errorf("Generation = %d, want 4", s.Generation)
+	}
+}
+
+func TestSessionExpired(t *testing.T) {
+	now := time.Now()
+
+	noTTL := &Session{LastActive: now.Add(-24 * time.Hour)}
+	if noTTL.Expired(now) {
+		t.Error("Expired() = true for zero TTL, want false")
+	}
+
+	fresh := &Session{LastActive: now, TTL: time.Hour}
+	if fresh.Expired(now) {
+		t.Error("Expired() = true for session within TTL, want false")
+	}
+
+	stale := &Session{LastActive: now.Add(-2 * time.Hour), TTL: time.Hour}
+	if !stale.Expired(now) {
+		t.Error("Expired() = false for session past TTL, want true")
+	}
+}
@@ -5,29 +5,55 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/user/teleclaude/internal/approval"
+	"github.com/user/teleclaude/internal/auth"
 	"github.com/user/teleclaude/internal/claude"
 	"github.com/user/teleclaude/internal/config"
+	"github.com/user/teleclaude/internal/runtime"
+	"github.com/user/teleclaude/internal/runtime/docker"
+	"github.com/user/teleclaude/internal/runtime/local"
 	"github.com/user/teleclaude/internal/session"
+	"github.com/user/teleclaude/internal/transport"
+	"github.com/user/teleclaude/internal/voice"
 	tele "gopkg.in/telebot.v4"
 )
 
 type Bot struct {
 	bot        *tele.Bot
-	cfg        *config.Config
+	cfgWatcher *config.Watcher
 	sessions   *session.Manager
 	approval   *approval.Workflow
+	policy     *approval.Engine
+	bans       *auth.BanList
 	formatter  *Formatter
 	keyboards  *KeyboardBuilder
 
+	rateLimiter *auth.RateLimiter
+	abuse       *auth.AbuseTracker
+
 	controllers map[string]*claude.Controller // sessionID -> controller
+	runtimes    map[string]runtime.Runtime     // sessionID -> runtime backend, reused across turns
 	mu          sync.RWMutex
+
+	runtimeUsers runtimeUserStore
+
+	externalHandler func(transport.UserMessage)
+
+	voiceTranscriber voice.Transcriber
+	voiceSynthesizer voice.Synthesizer
 }
 
-func NewBot(token string, cfg *config.Config, sessions *session.Manager, approvalWf *approval.Workflow, formatter *Formatter) (*Bot, error) {
+// var _ transport.Chat = (*Bot)(nil) asserts Bot satisfies the
+// chat-network-agnostic interface other transports (e.g. XMPP) implement,
+// so a future generic bridge can drive a session through either one.
+var _ transport.Chat = (*Bot)(nil)
+
+func NewBot(token string, cfg *config.Config, sessions *session.Manager, approvalWf *approval.Workflow, formatter *Formatter, bans *auth.BanList) (*Bot, error) {
 	if token == "" {
 		return nil, errors.New("telegram token required")
 	}
@@ -42,14 +68,27 @@ func NewBot(token string, cfg *config.Config, sessions *session.Manager, approva
 		return nil, fmt.Errorf("failed to create bot: %w", err)
 	}
 
+	policy, err := approval.NewEngine(cfg.ApprovalRules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile approval rules: %w", err)
+	}
+	if err := policy.SetPolicies(cfg.Approval.Policies); err != nil {
+		return nil, fmt.Errorf("failed to compile approval policies: %w", err)
+	}
+
 	b := &Bot{
 		bot:         teleBot,
-		cfg:         cfg,
+		cfgWatcher:  config.NewWatcher(cfg, validateReloadedConfig),
 		sessions:    sessions,
 		approval:    approvalWf,
+		policy:      policy,
+		bans:        bans,
 		formatter:   formatter,
 		keyboards:   NewKeyboardBuilder(),
 		controllers: make(map[string]*claude.Controller),
+		runtimes:    make(map[string]runtime.Runtime),
+		rateLimiter: auth.NewRateLimiter(cfg.RateLimit.MessagesPerMinute, time.Minute),
+		abuse:       auth.NewAbuseTracker(bans, cfg.RateLimit.AbuseThreshold, cfg.RateLimit.AbuseWindow.Duration, cfg.RateLimit.AutoBanTTL.Duration),
 	}
 
 	b.setupMiddleware()
@@ -58,16 +97,156 @@ func NewBot(token string, cfg *config.Config, sessions *session.Manager, approva
 	return b, nil
 }
 
+// cfg returns the currently active config.Config. Every read goes through
+// here rather than a field, so a config.Watcher reload (see WatchConfigFile)
+// is visible to the very next call without any caller holding a stale
+// pointer.
+func (b *Bot) cfg() *config.Config {
+	return b.cfgWatcher.Current()
+}
+
+// validateReloadedConfig is config.Watcher's validate hook: it must
+// succeed before a reloaded config is swapped in, so an invalid
+// approval_rules/approval.policies fails the reload atomically — the old
+// config, and the approval engine built from it, both stay fully in
+// place — instead of the config going live while the engine silently
+// keeps running the previous rules.
+func validateReloadedConfig(cfg *config.Config) error {
+	if err := approval.ValidateRules(cfg.ApprovalRules); err != nil {
+		return fmt.Errorf("approval_rules: %w", err)
+	}
+	if err := approval.ValidatePolicies(cfg.Approval.Policies); err != nil {
+		return fmt.Errorf("approval.policies: %w", err)
+	}
+	return nil
+}
+
+// WatchConfigFile starts hot-reloading path: on each write, the new config
+// is parsed and validated (including that its approval_rules/
+// approval.policies actually compile) before being atomically swapped in,
+// and every admin user is DMed a summary of what changed. A config that
+// fails to parse or validate leaves the old one in place and DMs admins
+// the error instead.
+func (b *Bot) WatchConfigFile(ctx context.Context, path string) error {
+	return b.cfgWatcher.Start(ctx, path, b.onConfigReload, b.onConfigReloadError)
+}
+
+// onConfigReload installs the already-validated new config's approval
+// rules and policies into the live engine. Since validateReloadedConfig
+// already proved they compile, ReplaceRules/SetPolicies are not expected
+// to fail here; the error branches are defensive, not a normal path.
+func (b *Bot) onConfigReload(diff config.Diff) {
+	if err := b.policy.ReplaceRules(b.cfg().ApprovalRules); err != nil {
+		log.Printf("config: reload installed a config whose approval_rules no longer compile: %v", err)
+		b.notifyAdmins(fmt.Sprintf("⚠️ config.yaml reloaded but approval_rules failed to install: %v", err))
+		return
+	}
+	if err := b.policy.SetPolicies(b.cfg().Approval.Policies); err != nil {
+		log.Printf("config: reload installed a config whose approval.policies no longer compile: %v", err)
+		b.notifyAdmins(fmt.Sprintf("⚠️ config.yaml reloaded but approval.policies failed to install: %v", err))
+		return
+	}
+
+	log.Printf("config: reloaded")
+	b.notifyAdmins(formatReloadDiff(diff))
+}
+
+// onConfigReloadError fires for both a config.yaml that fails to parse and
+// one that parses but fails validateReloadedConfig; either way the
+// previous config was never swapped out.
+func (b *Bot) onConfigReloadError(err error) {
+	log.Printf("config: reload failed: %v", err)
+	b.notifyAdmins(fmt.Sprintf("⚠️ config.yaml changed but failed to load: %v\nThe previous config is still active.", err))
+}
+
+// notifyAdmins DMs every RoleAdmin user currently in config.Users or
+// runtime-granted via /adduser. Best effort: a send failure (e.g. the
+// admin never started a chat with the bot) is logged and otherwise
+// ignored.
+func (b *Bot) notifyAdmins(text string) {
+	admins := append(append([]config.UserEntry{}, b.cfg().Users...), b.runtimeUsers.all()...)
+	for _, u := range admins {
+		if u.Role != approval.RoleAdmin {
+			continue
+		}
+		if _, err := b.bot.Send(tele.ChatID(u.ID), text); err != nil {
+			log.Printf("Failed to notify admin %d of config reload: %v", u.ID, err)
+		}
+	}
+}
+
+// formatReloadDiff renders a config.Diff as the text notifyAdmins sends.
+func formatReloadDiff(diff config.Diff) string {
+	if diff.IsEmpty() {
+		return "✅ config.yaml reloaded. No visible changes."
+	}
+
+	var sb strings.Builder
+	sb.WriteString("✅ config.yaml reloaded.\n")
+	for _, name := range diff.AddedProjects {
+		fmt.Fprintf(&sb, "+ project %s\n", name)
+	}
+	for _, name := range diff.RemovedProjects {
+		fmt.Fprintf(&sb, "- project %s\n", name)
+	}
+	if diff.RuleCountBefore != diff.RuleCountAfter {
+		fmt.Fprintf(&sb, "approval rules: %d -> %d\n", diff.RuleCountBefore, diff.RuleCountAfter)
+	}
+	return sb.String()
+}
+
 func (b *Bot) setupMiddleware() {
+	// Ban check runs before the allow-list so a banned user gets a clear
+	// "you're banned" message instead of the generic unauthorized one.
+	b.bot.Use(func(next tele.HandlerFunc) tele.HandlerFunc {
+		return func(c tele.Context) error {
+			if b.bans != nil {
+				if banned, entry := b.bans.IsUserBanned(c.Sender().ID, c.Sender().Username); banned {
+					return c.Send(formatBanMessage(entry))
+				}
+				if chat := c.Chat(); chat != nil {
+					if banned, entry := b.bans.IsChatBanned(chat.ID); banned {
+						return c.Send(formatBanMessage(entry))
+					}
+				}
+			}
+			return next(c)
+		}
+	})
+
 	// Auth middleware
 	b.bot.Use(func(next tele.HandlerFunc) tele.HandlerFunc {
 		return func(c tele.Context) error {
-			if !b.cfg.IsUserAllowed(c.Sender().ID) {
+			if !b.userAllowed(c.Sender().ID) {
 				return c.Send("Unauthorized. Your user ID is not in the allowed list.")
 			}
 			return next(c)
 		}
 	})
+
+	// Rate limiting runs last, after a message is known to be from an
+	// allowed, unbanned user, so it only throttles legitimate traffic.
+	b.bot.Use(func(next tele.HandlerFunc) tele.HandlerFunc {
+		return func(c tele.Context) error {
+			if b.rateLimiter != nil && !b.rateLimiter.Allow(c.Sender().ID) {
+				return c.Send("You're sending messages too fast. Please slow down.")
+			}
+			return next(c)
+		}
+	})
+}
+
+// requireRole wraps handler so it only runs for callers holding role or
+// RoleAdmin (admins satisfy any role requirement), replying with a refusal
+// otherwise. Used instead of each handler repeating its own IsAdmin check.
+func (b *Bot) requireRole(role approval.Role, handler tele.HandlerFunc) tele.HandlerFunc {
+	return func(c tele.Context) error {
+		userRole, ok := b.userRole(c.Sender().ID)
+		if !ok || (userRole != role && userRole != approval.RoleAdmin) {
+			return c.Send(fmt.Sprintf("This command requires the %s role.", role))
+		}
+		return handler(c)
+	}
 }
 
 func (b *Bot) setupHandlers() {
@@ -79,6 +258,17 @@ func (b *Bot) setupHandlers() {
 	b.bot.Handle("/switch", b.handleSwitch)
 	b.bot.Handle("/cost", b.handleCost)
 	b.bot.Handle("/cancel", b.handleCancel)
+	b.bot.Handle("/ban", b.requireRole(approval.RoleAdmin, b.handleBan))
+	b.bot.Handle("/unban", b.requireRole(approval.RoleAdmin, b.handleUnban))
+	b.bot.Handle("/banlist", b.requireRole(approval.RoleAdmin, b.handleBanList))
+	b.bot.Handle("/adduser", b.requireRole(approval.RoleAdmin, b.handleAddUser))
+	b.bot.Handle("/kill", b.requireRole(approval.RoleAdmin, b.handleKill))
+	b.bot.Handle("/reload", b.requireRole(approval.RoleAdmin, b.handleReload))
+	b.bot.Handle("/resize", b.handleResize)
+	b.bot.Handle("/gc", b.handleGC)
+	b.bot.Handle("/voice", b.handleVoiceCmd)
+	b.bot.Handle("/policy", b.handlePolicy)
+	b.bot.Handle(tele.OnVoice, b.handleVoiceNote)
 
 	// Callback queries for inline keyboards
 	b.bot.Handle(&tele.Btn{Unique: "project"}, b.handleProjectSelect)
@@ -92,20 +282,122 @@ func (b *Bot) setupHandlers() {
 	b.bot.Handle(tele.OnText, b.handleMessage)
 }
 
-func (b *Bot) Start() {
+// Start implements transport.Chat. It blocks until Stop is called.
+func (b *Bot) Start() error {
 	log.Println("TeleClaude bot starting...")
 	b.bot.Start()
+	return nil
 }
 
+// SendMessage implements transport.Chat, sending text to the Telegram chat
+// ID encoded in recipient.
+func (b *Bot) SendMessage(recipient, text string) error {
+	chatID, err := strconv.ParseInt(recipient, 10, 64)
+	if err != nil {
+		return fmt.Errorf("telegram: invalid recipient %q: %w", recipient, err)
+	}
+	_, err = b.bot.Send(tele.ChatID(chatID), text)
+	return err
+}
+
+// SendChunks implements transport.Chat by sending each chunk as its own
+// message.
+func (b *Bot) SendChunks(recipient string, chunks []string) error {
+	for _, chunk := range chunks {
+		if err := b.SendMessage(recipient, chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RequestApproval implements transport.Chat using the same inline-keyboard
+// flow handleApprovalRequest already drives for in-process approvals.
+func (b *Bot) RequestApproval(ctx context.Context, recipient string, req transport.ApprovalRequest) (bool, error) {
+	chatID, err := strconv.ParseInt(recipient, 10, 64)
+	if err != nil {
+		return false, fmt.Errorf("telegram: invalid recipient %q: %w", recipient, err)
+	}
+
+	reqID := b.approval.CreateRequest("", req.ToolName, req.Reason, req.Command)
+
+	text := b.formatter.FormatApprovalRequest(req.ToolName, req.Reason, req.Command)
+	if _, err := b.bot.Send(tele.ChatID(chatID), text, b.keyboards.ApprovalButtons(reqID)); err != nil {
+		return false, err
+	}
+
+	return b.approval.WaitForDecision(ctx, reqID)
+}
+
+// RepostPendingApprovals re-sends the approval-buttons message for every
+// request approval.Workflow.LoadPending replayed from its RequestStore, so
+// a bot restart doesn't strand a user's pending approval with no way to
+// answer it. Called once at startup, after LoadPending and after the bot
+// is otherwise ready to send messages.
+func (b *Bot) RepostPendingApprovals(requests []*approval.Request) {
+	for _, req := range requests {
+		sess, err := b.sessions.GetSession(req.SessionID)
+		if err != nil || sess == nil {
+			log.Printf("Skipping replayed approval %s: session %s not found", req.ID, req.SessionID)
+			continue
+		}
+
+		chatID, err := strconv.ParseInt(sess.TransportUserID, 10, 64)
+		if err != nil {
+			log.Printf("Skipping replayed approval %s: invalid transport user id %q", req.ID, sess.TransportUserID)
+			continue
+		}
+
+		text := "⚠️ Resuming after restart — this was still awaiting your approval:\n\n" +
+			b.formatter.FormatApprovalRequest(req.ToolName, req.Reason, req.Command)
+		if _, err := b.bot.Send(tele.ChatID(chatID), text, b.keyboards.ApprovalButtons(req.ID)); err != nil {
+			log.Printf("Failed to repost replayed approval %s: %v", req.ID, err)
+		}
+	}
+}
+
+// OnUserMessage implements transport.Chat. It's invoked alongside Bot's own
+// slash-command and message handlers, so other transports sharing a bridge
+// can observe messages Telegram already handles itself.
+func (b *Bot) OnUserMessage(handler func(transport.UserMessage)) {
+	b.externalHandler = handler
+}
+
+// SetVoice wires optional speech-to-text/text-to-speech backends, enabling
+// /voice, transcription of incoming voice notes, and spoken approval
+// prompts for sessions that opt in. Called from main when voice.enabled is
+// true in config; leaving both nil (the default) disables voice handling.
+func (b *Bot) SetVoice(transcriber voice.Transcriber, synthesizer voice.Synthesizer) {
+	b.voiceTranscriber = transcriber
+	b.voiceSynthesizer = synthesizer
+}
+
+// maxConcurrentStops bounds how many controllers are stopped at once so a
+// shutdown with hundreds of active sessions doesn't serialize on syscalls.
+const maxConcurrentStops = 16
+
 func (b *Bot) Stop() {
 	log.Println("TeleClaude bot stopping...")
 
-	// Stop all controllers
-	b.mu.Lock()
+	b.mu.RLock()
+	controllers := make([]*claude.Controller, 0, len(b.controllers))
 	for _, ctrl := range b.controllers {
-		ctrl.Stop()
+		controllers = append(controllers, ctrl)
 	}
-	b.mu.Unlock()
+	b.mu.RUnlock()
+
+	sem := make(chan struct{}, maxConcurrentStops)
+	var wg sync.WaitGroup
+	for _, ctrl := range controllers {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(ctrl *claude.Controller) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			ctrl.Stop(context.Background())
+		}(ctrl)
+	}
+	wg.Wait()
 
 	b.bot.Stop()
 }
@@ -122,25 +414,91 @@ func (b *Bot) setController(sessionID string, ctrl *claude.Controller) {
 	b.controllers[sessionID] = ctrl
 }
 
+// IsSessionRunning reports whether sessionID currently has a live Claude
+// controller attached. Used by session.Manager's janitor to decide whether
+// to renew a session's heartbeat instead of letting its TTL expire.
+func (b *Bot) IsSessionRunning(sessionID string) bool {
+	ctrl := b.getController(sessionID)
+	return ctrl != nil && ctrl.IsRunning()
+}
+
 func (b *Bot) removeController(sessionID string) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 	delete(b.controllers, sessionID)
 }
 
-func (b *Bot) sendStreamingResponse(c tele.Context, ctrl *claude.Controller, sessionID string) {
-	ctx := context.Background()
+// getOrCreateRuntime returns sessionID's runtime.Runtime backend, creating
+// one from cfg.Runtime on first use and reusing it on later turns so a
+// runtime/docker sandbox container persists across a conversation instead
+// of being rebuilt every prompt.
+func (b *Bot) getOrCreateRuntime(sessionID string) runtime.Runtime {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if rt, ok := b.runtimes[sessionID]; ok {
+		return rt
+	}
+
+	var rt runtime.Runtime
+	if b.cfg().Runtime.Backend == "docker" {
+		rt = docker.New(docker.Config{
+			Image:       b.cfg().Runtime.Image,
+			CPULimit:    b.cfg().Runtime.CPULimit,
+			MemoryLimit: b.cfg().Runtime.MemoryLimit,
+			Network:     b.cfg().Runtime.Network,
+		})
+	} else {
+		rt = local.New()
+	}
+	b.runtimes[sessionID] = rt
+	return rt
+}
+
+// TeardownRuntime closes and forgets sessionID's runtime backend, e.g. a
+// runtime/docker sandbox container. Wired as session.Manager's
+// SetOnIdle callback so an idle session's container is stopped instead of
+// sitting around between conversations.
+func (b *Bot) TeardownRuntime(sessionID string) {
+	b.mu.Lock()
+	rt, ok := b.runtimes[sessionID]
+	if ok {
+		delete(b.runtimes, sessionID)
+	}
+	b.mu.Unlock()
 
-	// Send initial message with cancel button
-	msg, err := b.bot.Send(c.Recipient(), "▌", b.keyboards.CancelButton(sessionID))
+	if ok {
+		if err := rt.Close(); err != nil {
+			log.Printf("Failed to close runtime for session %s: %v", sessionID, err)
+		}
+	}
+}
+
+// recordDeniedApproval logs a denied-approval abuse signal against the
+// session's owning user, whether the denial came from the policy engine's
+// auto_deny or a human clicking the Deny button. sess may be nil if the
+// session has since been removed; that's not itself suspicious, so it's a
+// no-op rather than an error.
+func (b *Bot) recordDeniedApproval(sess *session.Session) {
+	if b.abuse == nil || sess == nil {
+		return
+	}
+	userID, err := strconv.ParseInt(sess.TransportUserID, 10, 64)
 	if err != nil {
-		log.Printf("Failed to send initial message: %v", err)
 		return
 	}
+	b.abuse.Record(userID, auth.SignalDeniedApproval)
+}
+
+func (b *Bot) sendStreamingResponse(c tele.Context, ctrl *claude.Controller, sessionID string) {
+	ctx := context.Background()
+
+	minEditInterval := time.Duration(b.cfg().Streaming.MinEditIntervalMs) * time.Millisecond
+	renderer := newStreamRenderer(b.bot, c.Recipient(), b.keyboards, sessionID, b.cfg().Streaming.ChunkSize, b.cfg().Streaming.UsesNativeEdits(), minEditInterval)
 
 	var buffer string
-	lastEdit := time.Now()
-	rules := approval.NewRules(b.cfg.Approval.RequireFor)
+	lastThrottle := time.Time{}
+	sess, _ := b.sessions.GetSession(sessionID)
 
 	for claudeMsg := range ctrl.Output {
 		switch claudeMsg.Type {
@@ -159,10 +517,35 @@ func (b *Bot) sendStreamingResponse(c tele.Context, ctrl *claude.Controller, ses
 			annotation := b.formatter.FormatToolUse(claudeMsg)
 			buffer += "\n" + annotation + " "
 
-			// Check if approval needed
-			if rules.RequiresApproval(claudeMsg) {
-				// Pause for approval
-				b.handleApprovalRequest(ctx, c, ctrl, claudeMsg, sessionID)
+			// Check the policy engine for whether this tool call needs a
+			// human's approval, is auto-denied, or may just proceed.
+			decision := b.policy.Evaluate(claudeMsg, sess)
+			switch decision.Outcome {
+			case approval.OutcomeAsk:
+				b.handleApprovalRequest(ctx, c, ctrl, decision, sessionID)
+			case approval.OutcomeDeny:
+				buffer += fmt.Sprintf("\n🚫 Denied by policy (%s)", decision.RuleName)
+				ctrl.SendInput("n")
+				b.recordDeniedApproval(sess)
+			}
+
+		case claude.MessageTypeToolResult:
+			// Large tool output (a full file read, a long bash log) goes
+			// out as a document instead of inflating buffer past the
+			// streaming chunk size.
+			chunks, attachment, err := b.formatter.ChunkOrUpload(ctx, claudeMsg.Content, b.cfg().Streaming.UploadThresholdBytes)
+			if err != nil {
+				break
+			}
+			if attachment != nil {
+				b.bot.Send(c.Recipient(), &tele.Document{
+					File:     tele.FromReader(attachment.Reader),
+					FileName: attachment.Name,
+				})
+				break
+			}
+			for _, chunk := range chunks {
+				buffer += chunk
 			}
 
 		case claude.MessageTypeResult:
@@ -177,42 +560,30 @@ func (b *Bot) sendStreamingResponse(c tele.Context, ctrl *claude.Controller, ses
 		}
 
 		// Throttled edit
-		if time.Since(lastEdit) >= time.Duration(b.cfg.Streaming.EditThrottleMs)*time.Millisecond {
-			displayText := buffer
-			if ctrl.IsRunning() {
-				displayText += "▌"
-			}
-
-			// Chunk if needed
-			if len(displayText) > b.cfg.Streaming.ChunkSize {
-				// Send current buffer and start new message
-				b.bot.Edit(msg, buffer)
-				msg, _ = b.bot.Send(c.Recipient(), "▌", b.keyboards.CancelButton(sessionID))
+		if time.Since(lastThrottle) >= time.Duration(b.cfg().Streaming.EditThrottleMs)*time.Millisecond {
+			if renderer.Update(buffer, ctrl.IsRunning()) {
 				buffer = ""
-			} else {
-				b.bot.Edit(msg, displayText, b.keyboards.CancelButton(sessionID))
 			}
-			lastEdit = time.Now()
+			lastThrottle = time.Now()
 		}
 	}
 
-	// Final edit without cursor or cancel button
-	if buffer != "" {
-		b.bot.Edit(msg, buffer)
-	}
+	renderer.Finalize(buffer)
 }
 
-func (b *Bot) handleApprovalRequest(ctx context.Context, c tele.Context, ctrl *claude.Controller, msg *claude.Message, sessionID string) {
-	rules := approval.NewRules(b.cfg.Approval.RequireFor)
-	reason := rules.ExtractReason(msg)
-	command := rules.ExtractCommand(msg)
+// voiceSynthesizeTimeout bounds how long an approval prompt's TTS pass may
+// take; it never blocks the approval wait itself, just the best-effort
+// voice note send before it.
+const voiceSynthesizeTimeout = 15 * time.Second
 
+func (b *Bot) handleApprovalRequest(ctx context.Context, c tele.Context, ctrl *claude.Controller, decision approval.Decision, sessionID string) {
 	// Create approval request
-	reqID := b.approval.CreateRequest(sessionID, msg.ToolName, reason, command)
+	reqID := b.approval.CreateRequest(sessionID, decision.Tool, decision.Reason, decision.Command)
 
 	// Send approval prompt
-	text := b.formatter.FormatApprovalRequest(msg.ToolName, reason, command)
+	text := b.formatter.FormatApprovalRequest(decision.Tool, decision.Reason, decision.Command)
 	b.bot.Send(c.Recipient(), text, b.keyboards.ApprovalButtons(reqID))
+	b.sendApprovalVoiceNote(c, sessionID, decision.Reason, decision.Command)
 
 	// Wait for decision
 	approved, err := b.approval.WaitForDecision(ctx, reqID)
@@ -229,4 +600,39 @@ func (b *Bot) handleApprovalRequest(ctx context.Context, c tele.Context, ctrl *c
 	}
 }
 
+// sendApprovalVoiceNote speaks an approval prompt as a short voice note,
+// e.g. "Claude wants to run git push origin main. Reply yes or no.", when
+// voice synthesis is configured and the session has opted in via
+// /voice on. Best-effort: a failure here just means the user reads the
+// text prompt that was already sent.
+func (b *Bot) sendApprovalVoiceNote(c tele.Context, sessionID, reason, command string) {
+	if b.voiceSynthesizer == nil {
+		return
+	}
+
+	sess, err := b.sessions.GetSession(sessionID)
+	if err != nil || !sess.VoiceEnabled {
+		return
+	}
+
+	spoken := reason
+	if command != "" {
+		spoken = "run " + command
+	}
+	phrase := fmt.Sprintf("Claude wants to %s. Reply yes or no.", spoken)
+
+	ctx, cancel := context.WithTimeout(context.Background(), voiceSynthesizeTimeout)
+	defer cancel()
+
+	audio, err := b.voiceSynthesizer.Synthesize(ctx, phrase)
+	if err != nil {
+		log.Printf("voice: synthesis failed: %v", err)
+		return
+	}
+
+	if _, err := b.bot.Send(c.Recipient(), &tele.Voice{File: tele.FromReader(audio)}); err != nil {
+		log.Printf("voice: failed to send voice note: %v", err)
+	}
+}
+
 // Handler implementations moved to handlers.go (Task 14)
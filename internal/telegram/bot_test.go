@@ -11,7 +11,7 @@ func TestNewBotRequiresToken(t *testing.T) {
 		AllowedUsers: []int64{12345},
 	}
 
-	_, err := NewBot("", cfg, nil, nil, nil)
+	_, err := NewBot("", cfg, nil, nil, nil, nil)
 	if err == nil {
 		t.Error("NewBot() should fail with empty token")
 	}
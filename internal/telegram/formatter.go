@@ -1,18 +1,57 @@
 package telegram
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/user/teleclaude/internal/auth"
 	"github.com/user/teleclaude/internal/claude"
 )
 
+// defaultStreamWorkers and defaultPartSize size the concurrent assembler
+// ChunkOrUpload uses when an output is too large to send inline. Override
+// per instance with SetStreamWorkers/SetPartSize (streaming.workers/part_size
+// in config).
+const (
+	defaultStreamWorkers = 4
+	defaultPartSize      = 64 * 1024
+)
+
 type Formatter struct {
 	chunkSize int
+	workers   int
+	partSize  int
 }
 
 func NewFormatter(chunkSize int) *Formatter {
-	return &Formatter{chunkSize: chunkSize}
+	return &Formatter{
+		chunkSize: chunkSize,
+		workers:   defaultStreamWorkers,
+		partSize:  defaultPartSize,
+	}
+}
+
+// SetStreamWorkers configures how many goroutines ChunkOrUpload uses to
+// assemble a large output into a single attachment. Values <= 0 are
+// ignored, leaving the default in place.
+func (f *Formatter) SetStreamWorkers(n int) {
+	if n > 0 {
+		f.workers = n
+	}
+}
+
+// SetPartSize configures the byte range each assembler worker handles.
+// Values <= 0 are ignored, leaving the default in place.
+func (f *Formatter) SetPartSize(n int) {
+	if n > 0 {
+		f.partSize = n
+	}
 }
 
 var toolIcons = map[string]string{
@@ -77,6 +116,13 @@ func (f *Formatter) FormatToolUse(msg *claude.Message) string {
 	return "[" + icon + " " + detail + "]"
 }
 
+func formatBanMessage(entry *auth.BanEntry) string {
+	if remaining := entry.Remaining(); remaining > 0 {
+		return fmt.Sprintf("🚫 You are banned (%s). Time remaining: %s", entry.Reason, remaining.Round(time.Second))
+	}
+	return fmt.Sprintf("🚫 You are banned (%s).", entry.Reason)
+}
+
 func (f *Formatter) FormatApprovalRequest(toolName, reason, command string) string {
 	var sb strings.Builder
 	sb.WriteString("🔒 Approval needed\n\n")
@@ -118,6 +164,110 @@ func (f *Formatter) ChunkText(text string) []string {
 	return chunks
 }
 
+// Attachment is a large tool output destined for upload as a Telegram
+// document instead of a run of split messages.
+type Attachment struct {
+	Reader io.Reader
+	Name   string
+	Size   int64
+}
+
+// ChunkOrUpload decides between ChunkText's inline message splitting and a
+// single document upload: text at or under threshold is returned as
+// message chunks exactly like ChunkText; anything larger is assembled
+// concurrently into one Attachment instead, avoiding a long run of
+// 4096-byte-limited messages for a big file read or bash log. On success,
+// exactly one of the chunks and the attachment is non-nil; on error both
+// are nil.
+func (f *Formatter) ChunkOrUpload(ctx context.Context, text string, threshold int) ([]string, *Attachment, error) {
+	if len(text) <= threshold {
+		return f.ChunkText(text), nil, nil
+	}
+
+	data, err := f.assembleParts(ctx, text)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return nil, &Attachment{
+		Reader: bytes.NewReader(data),
+		Name:   "output.txt",
+		Size:   int64(len(data)),
+	}, nil
+}
+
+// assembleParts splits text into partSize byte ranges and fetches them
+// concurrently across workers goroutines, mirroring a multi-threaded
+// range-based download pipeline even though the source here is already a
+// buffered in-memory string rather than a remote resource.
+func (f *Formatter) assembleParts(ctx context.Context, text string) ([]byte, error) {
+	partSize := f.partSize
+	if partSize <= 0 {
+		partSize = defaultPartSize
+	}
+	workers := f.workers
+	if workers <= 0 {
+		workers = defaultStreamWorkers
+	}
+
+	numParts := (len(text) + partSize - 1) / partSize
+	parts := make([][]byte, numParts)
+
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				select {
+				case <-ctx.Done():
+					return
+				default:
+				}
+
+				start := index * partSize
+				end := start + partSize
+				if end > len(text) {
+					end = len(text)
+				}
+				parts[index] = []byte(text[start:end])
+			}
+		}()
+	}
+
+	// jobs is unbuffered and workers stop draining it as soon as ctx is
+	// done, so the producer has to select on ctx.Done() too - otherwise a
+	// cancellation mid-assembly leaves it blocked on jobs <- i forever.
+	go func() {
+		defer close(jobs)
+		for i := 0; i < numParts; i++ {
+			select {
+			case jobs <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	wg.Wait()
+
+	// Checked after every worker has returned, not raced against them via
+	// a separate errCh: ctx can be done before a single job is ever sent
+	// (jobs closes empty, workers range over it and exit without ever
+	// reaching their ctx.Done() check), which would otherwise report
+	// success with a truncated or empty buffer.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	for _, part := range parts {
+		buf.Write(part)
+	}
+	return buf.Bytes(), nil
+}
+
 func (f *Formatter) EscapeMarkdown(text string) string {
 	replacer := strings.NewReplacer(
 		"_", "\\_",
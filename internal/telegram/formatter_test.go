@@ -1,7 +1,11 @@
 package telegram
 
 import (
+	"context"
+	"io"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/user/teleclaude/internal/claude"
 )
@@ -91,6 +95,76 @@ func TestChunkText(t *testing.T) {
 	}
 }
 
+func TestChunkOrUploadUnderThreshold(t *testing.T) {
+	f := NewFormatter(3800)
+
+	chunks, attachment, err := f.ChunkOrUpload(context.Background(), "short output", 100)
+	if err != nil {
+		t.Fatalf("ChunkOrUpload() error = %v", err)
+	}
+	if attachment != nil {
+		t.Error("ChunkOrUpload() returned an attachment for text under threshold")
+	}
+	if len(chunks) != 1 || chunks[0] != "short output" {
+		t.Errorf("ChunkOrUpload() chunks = %v, want [short output]", chunks)
+	}
+}
+
+func TestChunkOrUploadOverThreshold(t *testing.T) {
+	f := NewFormatter(3800)
+	f.SetStreamWorkers(3)
+	f.SetPartSize(10)
+
+	text := strings.Repeat("abcdefghij", 20) // 200 bytes, in 10-byte parts
+
+	chunks, attachment, err := f.ChunkOrUpload(context.Background(), text, 50)
+	if err != nil {
+		t.Fatalf("ChunkOrUpload() error = %v", err)
+	}
+	if chunks != nil {
+		t.Error("ChunkOrUpload() returned chunks for text over threshold")
+	}
+	if attachment == nil {
+		t.Fatal("ChunkOrUpload() returned no attachment for text over threshold")
+	}
+	if attachment.Size != int64(len(text)) {
+		t.Errorf("Attachment.Size = %d, want %d", attachment.Size, len(text))
+	}
+
+	data, err := io.ReadAll(attachment.Reader)
+	if err != nil {
+		t.Fatalf("failed to read attachment: %v", err)
+	}
+	if string(data) != text {
+		t.Error("assembled attachment content does not match input, parts were reordered or dropped")
+	}
+}
+
+func TestChunkOrUploadReturnsOnCancellation(t *testing.T) {
+	f := NewFormatter(3800)
+	f.SetStreamWorkers(1)
+	f.SetPartSize(10)
+
+	text := strings.Repeat("abcdefghij", 2000) // many more parts than the single worker can race through
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if _, _, err := f.ChunkOrUpload(ctx, text, 50); err == nil {
+			t.Error("ChunkOrUpload() with an already-cancelled ctx should return an error")
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("ChunkOrUpload() deadlocked instead of returning on ctx cancellation")
+	}
+}
+
 func TestEscapeMarkdown(t *testing.T) {
 	f := NewFormatter(3800)
 
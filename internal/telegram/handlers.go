@@ -3,12 +3,30 @@ package telegram
 import (
 	"context"
 	"fmt"
+	"log"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/user/teleclaude/internal/approval"
+	"github.com/user/teleclaude/internal/auth"
 	"github.com/user/teleclaude/internal/claude"
+	"github.com/user/teleclaude/internal/session"
+	"github.com/user/teleclaude/internal/transport"
 	tele "gopkg.in/telebot.v4"
 )
 
+// voiceTranscribeTimeout bounds how long a voice note's transcription pass
+// may take before the user gets an error instead of a hung request.
+const voiceTranscribeTimeout = 30 * time.Second
+
+// telegramUserID converts a Telegram numeric user ID into the string form
+// session.Manager keys sessions by, since TransportUserID is shared across
+// networks whose native IDs aren't all integers (XMPP JIDs, for instance).
+func telegramUserID(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
 func (b *Bot) handleStart(c tele.Context) error {
 	text := `Welcome to TeleClaude!
 
@@ -19,9 +37,17 @@ Commands:
 /continue - Resume last session
 /sessions - List your sessions
 /cost - Show session costs
+/resize <cols> <rows> - Resize Claude's terminal
+/gc - Force an immediate session cleanup pass (admin)
+/voice on|off - Toggle spoken approval prompts for this session
+/policy - List and override approval rules
+/adduser <id> <role> - Grant a user access (admin)
+/kill <session id> - Stop any session's running operation (admin)
+/reload - Force an immediate config.yaml reload (admin)
 /help - Show this help
 
-Just send me a message to chat with Claude in your active session.`
+Just send me a message to chat with Claude in your active session. Voice
+notes are transcribed and treated the same way.`
 
 	return c.Send(text)
 }
@@ -38,7 +64,7 @@ func (b *Bot) handleNew(c tele.Context) error {
 		path := strings.Join(args, " ")
 
 		// Check if it's a registered project name
-		if projectPath, ok := b.cfg.Projects[path]; ok {
+		if projectPath, ok := b.cfg().Projects[path]; ok {
 			return b.startSession(c, projectPath, path)
 		}
 
@@ -50,15 +76,15 @@ func (b *Bot) handleNew(c tele.Context) error {
 	// TODO: Load recent from storage
 	recent := []string{}
 
-	markup := b.keyboards.ProjectSelector(b.cfg.Projects, recent)
+	markup := b.keyboards.ProjectSelector(b.cfg().Projects, recent)
 	return c.Send("Select a project or enter a path:", markup)
 }
 
 func (b *Bot) handleContinue(c tele.Context) error {
-	userID := c.Sender().ID
+	userID := telegramUserID(c.Sender().ID)
 
 	// Get last active session
-	sessions, err := b.sessions.GetUserSessions(userID)
+	sessions, err := b.sessions.GetUserSessions(transport.TypeTelegram, userID)
 	if err != nil || len(sessions) == 0 {
 		return c.Send("No sessions found. Use /new to start one.")
 	}
@@ -71,14 +97,14 @@ func (b *Bot) handleContinue(c tele.Context) error {
 		}
 	}
 
-	b.sessions.SetActiveSession(userID, latest)
+	b.sessions.SetActiveSession(transport.TypeTelegram, userID, latest)
 	return c.Send(fmt.Sprintf("Resumed session: %s\nProject: %s", latest.ID[:8], latest.ProjectName))
 }
 
 func (b *Bot) handleSessions(c tele.Context) error {
-	userID := c.Sender().ID
+	userID := telegramUserID(c.Sender().ID)
 
-	sessions, err := b.sessions.GetUserSessions(userID)
+	sessions, err := b.sessions.GetUserSessions(transport.TypeTelegram, userID)
 	if err != nil || len(sessions) == 0 {
 		return c.Send("No sessions found. Use /new to start one.")
 	}
@@ -94,9 +120,9 @@ func (b *Bot) handleSwitch(c tele.Context) error {
 	}
 
 	sessionID := args[0]
-	userID := c.Sender().ID
+	userID := telegramUserID(c.Sender().ID)
 
-	if err := b.sessions.SwitchSession(userID, sessionID); err != nil {
+	if err := b.sessions.SwitchSession(transport.TypeTelegram, userID, sessionID); err != nil {
 		return c.Send("Session not found.")
 	}
 
@@ -105,15 +131,15 @@ func (b *Bot) handleSwitch(c tele.Context) error {
 }
 
 func (b *Bot) handleCost(c tele.Context) error {
-	userID := c.Sender().ID
-	session := b.sessions.GetActiveSession(userID)
+	userID := telegramUserID(c.Sender().ID)
+	session := b.sessions.GetActiveSession(transport.TypeTelegram, userID)
 
 	if session == nil {
 		return c.Send("No active session.")
 	}
 
 	// Get all sessions for total
-	all, _ := b.sessions.GetUserSessions(userID)
+	all, _ := b.sessions.GetUserSessions(transport.TypeTelegram, userID)
 	var total float64
 	for _, s := range all {
 		total += s.TotalCostUSD
@@ -124,8 +150,8 @@ func (b *Bot) handleCost(c tele.Context) error {
 }
 
 func (b *Bot) handleCancel(c tele.Context) error {
-	userID := c.Sender().ID
-	session := b.sessions.GetActiveSession(userID)
+	userID := telegramUserID(c.Sender().ID)
+	session := b.sessions.GetActiveSession(transport.TypeTelegram, userID)
 
 	if session == nil {
 		return c.Send("No active session.")
@@ -136,19 +162,423 @@ func (b *Bot) handleCancel(c tele.Context) error {
 		return c.Send("No operation running.")
 	}
 
-	ctrl.Stop()
+	ctrl.Stop(context.Background())
 	return c.Send("Stopping operation...")
 }
 
+// handleBan implements /ban <user|chat|username> <id> [duration] [reason...]. Gated
+// to approval.RoleAdmin by requireRole in setupHandlers.
+func (b *Bot) handleBan(c tele.Context) error {
+	args := c.Args()
+	if len(args) < 2 {
+		return c.Send("Usage: /ban <user|chat|username> <id|username_glob> [duration] [reason...]")
+	}
+
+	dimension, err := parseBanDimension(args[0])
+	if err != nil {
+		return c.Send(err.Error())
+	}
+
+	target := args[1]
+	rest := args[2:]
+
+	var ttl time.Duration
+	if len(rest) > 0 {
+		if d, err := time.ParseDuration(rest[0]); err == nil {
+			ttl = d
+			rest = rest[1:]
+		}
+	}
+
+	reason := strings.Join(rest, " ")
+	if reason == "" {
+		reason = "no reason given"
+	}
+
+	if err := b.bans.Ban(dimension, target, reason, c.Sender().ID, ttl); err != nil {
+		return c.Send(fmt.Sprintf("Failed to ban: %v", err))
+	}
+
+	return c.Send(fmt.Sprintf("Banned %s %s: %s", dimension, target, reason))
+}
+
+// handleUnban implements /unban <user|chat|username> <id>. Gated to
+// approval.RoleAdmin by requireRole in setupHandlers.
+func (b *Bot) handleUnban(c tele.Context) error {
+	args := c.Args()
+	if len(args) < 2 {
+		return c.Send("Usage: /unban <user|chat|username> <id|username_glob>")
+	}
+
+	dimension, err := parseBanDimension(args[0])
+	if err != nil {
+		return c.Send(err.Error())
+	}
+
+	if err := b.bans.Unban(dimension, args[1]); err != nil {
+		return c.Send(fmt.Sprintf("Failed to unban: %v", err))
+	}
+
+	return c.Send(fmt.Sprintf("Unbanned %s %s", dimension, args[1]))
+}
+
+// handleBanList implements /banlist, listing all active bans. Gated to
+// approval.RoleAdmin by requireRole in setupHandlers.
+func (b *Bot) handleBanList(c tele.Context) error {
+	entries := b.bans.List()
+	if len(entries) == 0 {
+		return c.Send("No active bans.")
+	}
+
+	var sb strings.Builder
+	for _, entry := range entries {
+		sb.WriteString(fmt.Sprintf("%s %s — %s", entry.Dimension, entry.Target, entry.Reason))
+		if remaining := entry.Remaining(); remaining > 0 {
+			sb.WriteString(fmt.Sprintf(" (%s left)", remaining.Round(time.Second)))
+		}
+		sb.WriteString("\n")
+	}
+
+	return c.Send(sb.String())
+}
+
+// handleAddUser implements /adduser <telegram_id> <admin|operator|viewer>,
+// granting a user access at the given role. Gated to approval.RoleAdmin by
+// requireRole in setupHandlers. The grant is in-memory only for this
+// process's lifetime — unlike config.yaml it isn't written back to disk, so
+// a permanent grant still belongs in the allowed_users/users: config.
+func (b *Bot) handleAddUser(c tele.Context) error {
+	args := c.Args()
+	if len(args) != 2 {
+		return c.Send("Usage: /adduser <telegram_id> <admin|operator|viewer>")
+	}
+
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return c.Send("telegram_id must be a number")
+	}
+
+	role := approval.Role(strings.ToLower(args[1]))
+	switch role {
+	case approval.RoleAdmin, approval.RoleOperator, approval.RoleViewer:
+	default:
+		return c.Send("role must be one of: admin, operator, viewer")
+	}
+
+	b.runtimeUsers.add(id, role)
+	return c.Send(fmt.Sprintf("Added user %d as %s (until the bot restarts; update config.yaml to persist).", id, role))
+}
+
+// handleReload implements /reload, forcing an immediate config.yaml reload
+// instead of waiting for WatchConfigFile's debounced file-event handler to
+// notice the write. Gated to approval.RoleAdmin by requireRole in
+// setupHandlers.
+func (b *Bot) handleReload(c tele.Context) error {
+	// Reload validates (including that approval_rules/approval.policies
+	// compile) before swapping the config in, so a failure here always
+	// means the previous config is still the active one.
+	diff, err := b.cfgWatcher.Reload()
+	if err != nil {
+		return c.Send(fmt.Sprintf("Reload failed, previous config is still active: %v", err))
+	}
+
+	if err := b.policy.ReplaceRules(b.cfg().ApprovalRules); err != nil {
+		return c.Send(fmt.Sprintf("Reloaded, but approval_rules failed to install: %v", err))
+	}
+	if err := b.policy.SetPolicies(b.cfg().Approval.Policies); err != nil {
+		return c.Send(fmt.Sprintf("Reloaded, but approval.policies failed to install: %v", err))
+	}
+
+	return c.Send(formatReloadDiff(diff))
+}
+
+// handleKill implements /kill <session id>, stopping any session's running
+// Claude process regardless of who owns it. Gated to approval.RoleAdmin by
+// requireRole in setupHandlers.
+func (b *Bot) handleKill(c tele.Context) error {
+	args := c.Args()
+	if len(args) != 1 {
+		return c.Send("Usage: /kill <session id>")
+	}
+
+	sessionID := args[0]
+	if _, err := b.sessions.GetSession(sessionID); err != nil {
+		return c.Send("Session not found.")
+	}
+
+	ctrl := b.getController(sessionID)
+	if ctrl == nil || !ctrl.IsRunning() {
+		return c.Send("No operation running for that session.")
+	}
+
+	ctrl.ForceStop()
+	return c.Send(fmt.Sprintf("Killed session %s.", sessionID[:8]))
+}
+
+func parseBanDimension(kind string) (auth.Dimension, error) {
+	switch strings.ToLower(kind) {
+	case "user":
+		return auth.DimensionUser, nil
+	case "chat":
+		return auth.DimensionChat, nil
+	case "username":
+		return auth.DimensionUsername, nil
+	default:
+		return "", fmt.Errorf("unknown ban target %q, expected user|chat|username", kind)
+	}
+}
+
+// handleResize implements /resize <cols> <rows>, widening or narrowing the
+// PTY of the active session's running Claude process.
+func (b *Bot) handleResize(c tele.Context) error {
+	userID := telegramUserID(c.Sender().ID)
+	session := b.sessions.GetActiveSession(transport.TypeTelegram, userID)
+	if session == nil {
+		return c.Send("No active session.")
+	}
+
+	args := c.Args()
+	if len(args) != 2 {
+		return c.Send("Usage: /resize <cols> <rows>")
+	}
+
+	cols, err := strconv.ParseUint(args[0], 10, 16)
+	if err != nil {
+		return c.Send("cols must be a number")
+	}
+	rows, err := strconv.ParseUint(args[1], 10, 16)
+	if err != nil {
+		return c.Send("rows must be a number")
+	}
+
+	ctrl := b.getController(session.ID)
+	if ctrl == nil {
+		return c.Send("No operation running.")
+	}
+
+	if err := ctrl.Resize(uint16(cols), uint16(rows)); err != nil {
+		return c.Send(fmt.Sprintf("Failed to resize: %v", err))
+	}
+
+	return c.Send(fmt.Sprintf("Resized to %dx%d", cols, rows))
+}
+
+// handleGC implements /gc, forcing an immediate session janitor pass
+// instead of waiting for the next renew_interval tick. Admin-only since it
+// can expire other users' idle sessions.
+func (b *Bot) handleGC(c tele.Context) error {
+	if !b.cfg().IsAdmin(c.Sender().ID) {
+		return c.Send("Only admins can force garbage collection.")
+	}
+
+	expired, err := b.sessions.GC(b.cfg().Sessions.Retention.Duration, b.IsSessionRunning)
+	if err != nil {
+		return c.Send(fmt.Sprintf("GC failed: %v", err))
+	}
+
+	return c.Send(fmt.Sprintf("GC complete. %d session(s) newly expired.", expired))
+}
+
 func (b *Bot) handleMessage(c tele.Context) error {
-	userID := c.Sender().ID
-	session := b.sessions.GetActiveSession(userID)
+	return b.handlePrompt(c, c.Text())
+}
+
+// handleVoiceNote transcribes an incoming Telegram voice note and feeds the
+// result into the same prompt pipeline as a typed message, echoing the
+// transcript back first so the user can tell what Claude will see.
+func (b *Bot) handleVoiceNote(c tele.Context) error {
+	if b.voiceTranscriber == nil {
+		return c.Send("Voice input isn't enabled on this bot.")
+	}
+
+	v := c.Message().Voice
+	if v == nil {
+		return nil
+	}
+
+	reader, err := b.bot.File(&v.File)
+	if err != nil {
+		return c.Send(fmt.Sprintf("Failed to download voice message: %v", err))
+	}
+	defer reader.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), voiceTranscribeTimeout)
+	defer cancel()
+
+	text, err := b.voiceTranscriber.Transcribe(ctx, reader)
+	if err != nil {
+		return c.Send(fmt.Sprintf("Transcription failed: %v", err))
+	}
+	if strings.TrimSpace(text) == "" {
+		return c.Send("Couldn't make out any words in that voice message.")
+	}
+
+	c.Send(fmt.Sprintf("🎤 %s", text))
+	return b.handlePrompt(c, text)
+}
+
+// handleVoiceCmd implements /voice [on|off], toggling whether approval
+// prompts on the active session are also sent as a spoken voice note.
+func (b *Bot) handleVoiceCmd(c tele.Context) error {
+	userID := telegramUserID(c.Sender().ID)
+	session := b.sessions.GetActiveSession(transport.TypeTelegram, userID)
+	if session == nil {
+		return c.Send("No active session.")
+	}
+
+	args := c.Args()
+	if len(args) == 0 {
+		state := "off"
+		if session.VoiceEnabled {
+			state = "on"
+		}
+		return c.Send(fmt.Sprintf("Voice replies are %s for this session. Usage: /voice on|off", state))
+	}
+
+	var enabled bool
+	switch strings.ToLower(args[0]) {
+	case "on":
+		enabled = true
+	case "off":
+		enabled = false
+	default:
+		return c.Send("Usage: /voice on|off")
+	}
+
+	if err := b.sessions.SetVoicePreference(session.ID, enabled); err != nil {
+		return c.Send(fmt.Sprintf("Failed to update voice preference: %v", err))
+	}
+
+	state := "off"
+	if enabled {
+		state = "on"
+	}
+	return c.Send(fmt.Sprintf("Voice replies are now %s for this session.", state))
+}
+
+// handlePolicy implements /policy: listing the compiled global approval
+// rules and their enabled state, toggling a global rule (admin only), and
+// adding/removing per-session overrides.
+//
+// Usage:
+//
+//	/policy                          - list global rules and their state
+//	/policy enable|disable <rule>    - toggle a global rule (admin only)
+//	/policy allow|deny <tool> [cmd]  - add a session override, optionally scoped to commands starting with cmd
+//	/policy clear <override name>    - remove a session override
+func (b *Bot) handlePolicy(c tele.Context) error {
+	args := c.Args()
+	if len(args) == 0 {
+		return b.listPolicy(c)
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "enable", "disable":
+		if !b.cfg().IsAdmin(c.Sender().ID) {
+			return c.Send("Only admins can toggle global approval rules.")
+		}
+		if len(args) < 2 {
+			return c.Send("Usage: /policy enable|disable <rule name>")
+		}
+		name := strings.Join(args[1:], " ")
+		if err := b.policy.SetRuleEnabled(name, strings.ToLower(args[0]) == "enable"); err != nil {
+			return c.Send(err.Error())
+		}
+		return c.Send(fmt.Sprintf("Rule %q is now %sd.", name, strings.ToLower(args[0])))
+
+	case "allow", "deny":
+		userID := telegramUserID(c.Sender().ID)
+		sess := b.sessions.GetActiveSession(transport.TypeTelegram, userID)
+		if sess == nil {
+			return c.Send("No active session.")
+		}
+		if len(args) < 2 {
+			return c.Send("Usage: /policy allow|deny <tool> [command prefix]")
+		}
+
+		tool := args[1]
+		prefix := strings.Join(args[2:], " ")
+		action := "auto_allow"
+		if strings.ToLower(args[0]) == "deny" {
+			action = "auto_deny"
+		}
+		name := fmt.Sprintf("%s:%s:%s", action, tool, prefix)
+
+		override := session.ApprovalOverride{Name: name, Tool: tool, CommandHasPrefix: prefix, Action: action}
+		if err := b.sessions.AddApprovalOverride(sess.ID, override); err != nil {
+			return c.Send(fmt.Sprintf("Failed to save override: %v", err))
+		}
+		return c.Send(fmt.Sprintf("Session override added: %s %s", args[0], name))
+
+	case "clear":
+		userID := telegramUserID(c.Sender().ID)
+		sess := b.sessions.GetActiveSession(transport.TypeTelegram, userID)
+		if sess == nil {
+			return c.Send("No active session.")
+		}
+		if len(args) < 2 {
+			return c.Send("Usage: /policy clear <override name>")
+		}
+
+		name := strings.Join(args[1:], " ")
+		removed, err := b.sessions.RemoveApprovalOverride(sess.ID, name)
+		if err != nil {
+			return c.Send(fmt.Sprintf("Failed to remove override: %v", err))
+		}
+		if !removed {
+			return c.Send("No override with that name.")
+		}
+		return c.Send("Override removed.")
+
+	default:
+		return c.Send("Usage: /policy [enable|disable <rule>] [allow|deny <tool> [prefix]] [clear <name>]")
+	}
+}
+
+// listPolicy shows every compiled global rule plus this session's
+// overrides, the no-argument form of /policy.
+func (b *Bot) listPolicy(c tele.Context) error {
+	var sb strings.Builder
+	sb.WriteString("Approval rules:\n")
+	for _, rs := range b.policy.ListRules() {
+		state := "enabled"
+		if !rs.Enabled {
+			state = "disabled"
+		}
+		fmt.Fprintf(&sb, "- %s (%s) [%s]\n", rs.Name, rs.Action, state)
+	}
+
+	userID := telegramUserID(c.Sender().ID)
+	if sess := b.sessions.GetActiveSession(transport.TypeTelegram, userID); sess != nil && len(sess.ApprovalOverrides) > 0 {
+		sb.WriteString("\nSession overrides:\n")
+		for _, o := range sess.ApprovalOverrides {
+			fmt.Fprintf(&sb, "- %s: %s on %s\n", o.Name, o.Action, o.Tool)
+		}
+	}
+
+	return c.Send(sb.String())
+}
+
+// handlePrompt drives Claude with prompt, regardless of whether it arrived
+// as a typed message or a transcribed voice note.
+func (b *Bot) handlePrompt(c tele.Context, prompt string) error {
+	userID := telegramUserID(c.Sender().ID)
+	session := b.sessions.GetActiveSession(transport.TypeTelegram, userID)
+
+	if b.externalHandler != nil {
+		b.externalHandler(transport.UserMessage{
+			Transport: transport.TypeTelegram,
+			UserID:    userID,
+			Recipient: strconv.FormatInt(c.Chat().ID, 10),
+			Text:      prompt,
+		})
+	}
 
 	if session == nil {
 		return c.Send("No active session. Use /new to start one or /continue to resume.")
 	}
 
-	prompt := c.Text()
+	b.sessions.RenewSession(session.ID)
 
 	// Check if there's already a running controller
 	existingCtrl := b.getController(session.ID)
@@ -159,9 +589,13 @@ func (b *Bot) handleMessage(c tele.Context) error {
 	// Create new controller
 	ctrl := claude.NewController(
 		session.ProjectPath,
-		b.cfg.Claude.MaxTurns,
-		b.cfg.Claude.PermissionMode,
+		b.cfg().Claude.MaxTurns,
+		b.cfg().Claude.PermissionMode,
 	)
+	ctrl.SetWinSize(uint16(b.cfg().Claude.PTYCols), uint16(b.cfg().Claude.PTYRows))
+	ctrl.SetStopGrace(time.Duration(b.cfg().Claude.StopGraceMs) * time.Millisecond)
+	ctrl.SetRuntime(b.getOrCreateRuntime(session.ID))
+	ctrl.SetSession(session)
 
 	// Set resume ID if available
 	if session.ClaudeSessionID != "" {
@@ -173,6 +607,9 @@ func (b *Bot) handleMessage(c tele.Context) error {
 	// Start Claude
 	if err := ctrl.Start(context.Background(), prompt); err != nil {
 		b.removeController(session.ID)
+		if b.abuse != nil {
+			b.abuse.Record(c.Sender().ID, auth.SignalFailedMessage)
+		}
 		return c.Send(fmt.Sprintf("Failed to start Claude: %v", err))
 	}
 
@@ -189,7 +626,7 @@ func (b *Bot) handleMessage(c tele.Context) error {
 
 func (b *Bot) handleProjectSelect(c tele.Context) error {
 	projectName := c.Callback().Data
-	projectPath, ok := b.cfg.Projects[projectName]
+	projectPath, ok := b.cfg().Projects[projectName]
 	if !ok {
 		return c.Respond(&tele.CallbackResponse{Text: "Project not found"})
 	}
@@ -206,9 +643,9 @@ func (b *Bot) handleRecentSelect(c tele.Context) error {
 
 func (b *Bot) handleSessionSwitch(c tele.Context) error {
 	sessionID := c.Callback().Data
-	userID := c.Sender().ID
+	userID := telegramUserID(c.Sender().ID)
 
-	if err := b.sessions.SwitchSession(userID, sessionID); err != nil {
+	if err := b.sessions.SwitchSession(transport.TypeTelegram, userID, sessionID); err != nil {
 		return c.Respond(&tele.CallbackResponse{Text: "Session not found"})
 	}
 
@@ -226,6 +663,11 @@ func (b *Bot) handleApprove(c tele.Context) error {
 
 func (b *Bot) handleDeny(c tele.Context) error {
 	reqID := c.Callback().Data
+	if req := b.approval.GetRequest(reqID); req != nil {
+		if sess, err := b.sessions.GetSession(req.SessionID); err == nil {
+			b.recordDeniedApproval(sess)
+		}
+	}
 	b.approval.Deny(reqID)
 	c.Respond(&tele.CallbackResponse{Text: "Denied"})
 	return c.Edit("❌ Denied")
@@ -240,7 +682,7 @@ func (b *Bot) handleCancelCallback(c tele.Context) error {
 	}
 
 	if ctrl.IsRunning() {
-		ctrl.Stop()
+		ctrl.Stop(context.Background())
 		c.Respond(&tele.CallbackResponse{Text: "Stopping..."})
 	} else {
 		ctrl.ForceStop()
@@ -251,7 +693,11 @@ func (b *Bot) handleCancelCallback(c tele.Context) error {
 }
 
 func (b *Bot) startSession(c tele.Context, projectPath, projectName string) error {
-	userID := c.Sender().ID
+	if role, ok := b.userRole(c.Sender().ID); ok && role == approval.RoleViewer {
+		return c.Send("Viewers can't start sessions.")
+	}
+
+	userID := telegramUserID(c.Sender().ID)
 
 	if projectName == "" {
 		// Extract from path
@@ -261,11 +707,17 @@ func (b *Bot) startSession(c tele.Context, projectPath, projectName string) erro
 		}
 	}
 
-	session, err := b.sessions.CreateSession(userID, projectPath, projectName)
+	session, err := b.sessions.CreateSession(transport.TypeTelegram, userID, projectPath, projectName)
 	if err != nil {
 		return c.Send(fmt.Sprintf("Failed to create session: %v", err))
 	}
 
+	if role, ok := b.userRole(c.Sender().ID); ok {
+		if err := b.sessions.SetRole(session.ID, string(role)); err != nil {
+			log.Printf("Failed to set role on session %s: %v", session.ID, err)
+		}
+	}
+
 	return c.Send(fmt.Sprintf("Started session: %s\nProject: %s\nPath: %s",
 		session.ID[:8], projectName, projectPath))
 }
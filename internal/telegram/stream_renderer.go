@@ -0,0 +1,133 @@
+package telegram
+
+import (
+	"hash/fnv"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// liveMessage is one message streamRenderer is still willing to edit,
+// together with a hash of the content it was last edited to so repeat
+// edits with unchanged content can be skipped (Telegram rejects those as
+// "message is not modified").
+type liveMessage struct {
+	msg      *tele.Message
+	lastHash uint64
+}
+
+// streamRenderer renders a Claude session's growing output buffer into
+// Telegram messages, editing in place instead of resending on every delta.
+type streamRenderer struct {
+	bot       *tele.Bot
+	recipient tele.Recipient
+	keyboards *KeyboardBuilder
+	sessionID string
+
+	chunkSize       int
+	nativeEdits     bool
+	minEditInterval time.Duration
+
+	current  *liveMessage
+	lastEdit time.Time
+}
+
+func newStreamRenderer(bot *tele.Bot, recipient tele.Recipient, keyboards *KeyboardBuilder, sessionID string, chunkSize int, nativeEdits bool, minEditInterval time.Duration) *streamRenderer {
+	return &streamRenderer{
+		bot:             bot,
+		recipient:       recipient,
+		keyboards:       keyboards,
+		sessionID:       sessionID,
+		chunkSize:       chunkSize,
+		nativeEdits:     nativeEdits,
+		minEditInterval: minEditInterval,
+	}
+}
+
+func hashText(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return h.Sum64()
+}
+
+// Update renders buffer (with a trailing cursor while running). It returns
+// true if buffer overflowed chunkSize and the caller should reset its
+// buffer and keep accumulating fresh content into a new message.
+func (r *streamRenderer) Update(buffer string, running bool) bool {
+	if time.Since(r.lastEdit) < r.minEditInterval {
+		return false
+	}
+	r.lastEdit = time.Now()
+
+	display := buffer
+	if running {
+		display += "▌"
+	}
+
+	if !r.nativeEdits {
+		r.send(display, running)
+		return len(display) > r.chunkSize
+	}
+
+	if r.current == nil {
+		r.current = r.send(display, running)
+		return false
+	}
+
+	if len(display) > r.chunkSize {
+		r.edit(r.current, buffer, false)
+		r.current = r.send("▌", running)
+		return true
+	}
+
+	r.edit(r.current, display, running)
+	return false
+}
+
+// Finalize sends the closing edit with no cursor and no cancel button.
+func (r *streamRenderer) Finalize(buffer string) {
+	if buffer == "" {
+		return
+	}
+
+	if !r.nativeEdits || r.current == nil {
+		r.bot.Send(r.recipient, buffer)
+		return
+	}
+
+	r.edit(r.current, buffer, false)
+}
+
+func (r *streamRenderer) send(text string, withCancel bool) *liveMessage {
+	var markup *tele.ReplyMarkup
+	if withCancel && r.keyboards != nil {
+		markup = r.keyboards.CancelButton(r.sessionID)
+	}
+
+	msg, err := r.bot.Send(r.recipient, text, markup)
+	if err != nil {
+		return nil
+	}
+	return &liveMessage{msg: msg, lastHash: hashText(text)}
+}
+
+func (r *streamRenderer) edit(live *liveMessage, text string, withCancel bool) {
+	if live == nil {
+		return
+	}
+
+	hash := hashText(text)
+	if hash == live.lastHash {
+		return
+	}
+
+	var markup *tele.ReplyMarkup
+	if withCancel && r.keyboards != nil {
+		markup = r.keyboards.CancelButton(r.sessionID)
+	}
+
+	if _, err := r.bot.Edit(live.msg, text, markup); err != nil {
+		return
+	}
+	live.lastHash = hash
+}
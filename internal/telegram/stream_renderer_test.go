@@ -0,0 +1,19 @@
+package telegram
+
+import "testing"
+
+func TestHashTextStableForSameContent(t *testing.T) {
+	a := hashText("hello world")
+	b := hashText("hello world")
+	if a != b {
+		t.Error("hashText() should be stable for identical input")
+	}
+}
+
+func TestHashTextDiffersForDifferentContent(t *testing.T) {
+	a := hashText("hello world")
+	b := hashText("hello world!")
+	if a == b {
+		t.Error("hashText() should differ for different input")
+	}
+}
@@ -0,0 +1,75 @@
+package telegram
+
+import (
+	"sync"
+
+	"github.com/user/teleclaude/internal/approval"
+	"github.com/user/teleclaude/internal/config"
+)
+
+// runtimeUserStore holds users granted access via /adduser: a mutex-owned
+// overlay on top of the config.Config snapshot b.cfg() returns. Appending to
+// b.cfg().Users directly would race every concurrent reader of that
+// snapshot (requireRole, UserRole, notifyAdmins, the auth middleware) and
+// get silently discarded the next time config.Watcher swaps in a reloaded
+// config.
+type runtimeUserStore struct {
+	mu    sync.RWMutex
+	users []config.UserEntry
+}
+
+// add grants id access at role, overwriting any earlier runtime grant for
+// the same id.
+func (s *runtimeUserStore) add(id int64, role approval.Role) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.users {
+		if s.users[i].ID == id {
+			s.users[i].Role = role
+			return
+		}
+	}
+	s.users = append(s.users, config.UserEntry{ID: id, Role: role})
+}
+
+// role returns id's runtime-granted Role, if any.
+func (s *runtimeUserStore) role(id int64) (approval.Role, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, u := range s.users {
+		if u.ID == id {
+			return u.Role, true
+		}
+	}
+	return "", false
+}
+
+// all returns a snapshot of every runtime-granted user, for callers (e.g.
+// notifyAdmins) that need to enumerate alongside config.Config.Users.
+func (s *runtimeUserStore) all() []config.UserEntry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]config.UserEntry, len(s.users))
+	copy(out, s.users)
+	return out
+}
+
+// userRole returns id's effective Role: config.Config.Users takes
+// precedence, falling back to a runtime /adduser grant for this process's
+// lifetime.
+func (b *Bot) userRole(id int64) (approval.Role, bool) {
+	if role, ok := b.cfg().UserRole(id); ok {
+		return role, true
+	}
+	return b.runtimeUsers.role(id)
+}
+
+// userAllowed reports whether id may use the bot at all, via config.Config
+// or a runtime /adduser grant.
+func (b *Bot) userAllowed(id int64) bool {
+	if b.cfg().IsUserAllowed(id) {
+		return true
+	}
+	_, ok := b.runtimeUsers.role(id)
+	return ok
+}
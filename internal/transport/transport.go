@@ -0,0 +1,61 @@
+// Package transport defines the chat-network-agnostic contract new
+// TeleClaude front ends implement so a single Claude session can be driven
+// from more than one network (Telegram, XMPP, ...) the way a telegabber-style
+// gateway fronts several chat protocols from one process.
+package transport
+
+import "context"
+
+// Type identifies which network a UserMessage or session originated from.
+type Type string
+
+const (
+	TypeTelegram Type = "telegram"
+	TypeXMPP     Type = "xmpp"
+)
+
+// UserMessage is one inbound message, normalized across transports.
+type UserMessage struct {
+	Transport Type
+	UserID    string // transport-native user identifier (Telegram user ID, XMPP bare JID, ...)
+	Recipient string // where replies should be sent; passed back into SendMessage/SendChunks
+	Text      string
+}
+
+// ApprovalRequest describes a tool call awaiting a yes/no decision from a
+// user, independent of how that network solicits the answer (inline
+// keyboard, plain-text reply, ...).
+type ApprovalRequest struct {
+	ToolName string
+	Reason   string
+	Command  string
+}
+
+// Chat is the interface a chat-network adapter implements to plug into
+// TeleClaude. It covers only what's common to every network; adapters are
+// free to expose a richer, network-specific surface on top (Telegram's
+// inline keyboards and slash commands, for instance).
+type Chat interface {
+	// SendMessage sends a single text message to recipient.
+	SendMessage(recipient, text string) error
+
+	// SendChunks sends text already split across multiple messages, for
+	// output too large to fit in one (see Formatter.ChunkText).
+	SendChunks(recipient string, chunks []string) error
+
+	// RequestApproval asks recipient to approve or deny a tool call and
+	// blocks until they respond or ctx is cancelled.
+	RequestApproval(ctx context.Context, recipient string, req ApprovalRequest) (bool, error)
+
+	// OnUserMessage registers the callback invoked for every inbound
+	// message the adapter doesn't consume itself. Registering a second
+	// handler replaces the first.
+	OnUserMessage(handler func(UserMessage))
+
+	// Start begins receiving messages. It blocks until the transport
+	// stops or hits a fatal error.
+	Start() error
+
+	// Stop shuts the transport down.
+	Stop()
+}
@@ -0,0 +1,76 @@
+package voice
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// PiperConfig configures the piper subprocess Synthesizer backend.
+type PiperConfig struct {
+	BinPath    string // path to the piper CLI binary, default "piper"
+	ModelPath  string // path to a piper voice model (.onnx)
+	FFmpegPath string // path to ffmpeg, used to encode piper's WAV output as OGG/Opus for Telegram's voice note player; default "ffmpeg"
+}
+
+// PiperSynthesizer synthesizes speech by shelling out to a local piper
+// build. Other backends (a cloud TTS API, say) only need to satisfy the
+// Synthesizer interface.
+type PiperSynthesizer struct {
+	cfg PiperConfig
+}
+
+// NewPiperSynthesizer returns a Synthesizer backed by piper, filling in
+// cfg's binary paths with their defaults if left blank.
+func NewPiperSynthesizer(cfg PiperConfig) *PiperSynthesizer {
+	if cfg.BinPath == "" {
+		cfg.BinPath = "piper"
+	}
+	if cfg.FFmpegPath == "" {
+		cfg.FFmpegPath = "ffmpeg"
+	}
+	return &PiperSynthesizer{cfg: cfg}
+}
+
+var _ Synthesizer = (*PiperSynthesizer)(nil)
+
+func (p *PiperSynthesizer) synthesizeArgs(outPath string) []string {
+	return []string{"-m", p.cfg.ModelPath, "-f", outPath}
+}
+
+func (p *PiperSynthesizer) encodeArgs(wavPath, oggPath string) []string {
+	return []string{"-y", "-i", wavPath, "-c:a", "libopus", oggPath}
+}
+
+func (p *PiperSynthesizer) Synthesize(ctx context.Context, text string) (io.Reader, error) {
+	tmpDir, err := os.MkdirTemp("", "teleclaude-tts")
+	if err != nil {
+		return nil, fmt.Errorf("voice: failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	wavPath := filepath.Join(tmpDir, "out.wav")
+	synth := exec.CommandContext(ctx, p.cfg.BinPath, p.synthesizeArgs(wavPath)...)
+	synth.Stdin = strings.NewReader(text)
+	if out, err := synth.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("voice: piper failed: %w: %s", err, out)
+	}
+
+	oggPath := filepath.Join(tmpDir, "out.ogg")
+	encode := exec.CommandContext(ctx, p.cfg.FFmpegPath, p.encodeArgs(wavPath, oggPath)...)
+	if out, err := encode.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("voice: ffmpeg encode failed: %w: %s", err, out)
+	}
+
+	data, err := os.ReadFile(oggPath)
+	if err != nil {
+		return nil, fmt.Errorf("voice: reading synthesized audio: %w", err)
+	}
+
+	return bytes.NewReader(data), nil
+}
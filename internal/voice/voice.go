@@ -0,0 +1,21 @@
+// Package voice lets the bot accept spoken prompts and speak approval
+// prompts back, behind small interfaces so the speech-to-text and
+// text-to-speech backends are swappable independently of telegram.Bot.
+package voice
+
+import (
+	"context"
+	"io"
+)
+
+// Transcriber turns recorded audio (a Telegram voice note, OGG/Opus
+// encoded) into text.
+type Transcriber interface {
+	Transcribe(ctx context.Context, audio io.Reader) (string, error)
+}
+
+// Synthesizer turns text into a short spoken audio clip suitable for
+// sending back as a Telegram voice note.
+type Synthesizer interface {
+	Synthesize(ctx context.Context, text string) (io.Reader, error)
+}
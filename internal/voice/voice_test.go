@@ -0,0 +1,69 @@
+package voice
+
+import "testing"
+
+func TestWhisperTranscribeArgs(t *testing.T) {
+	w := NewWhisperTranscriber(WhisperConfig{ModelPath: "/models/ggml-base.en.bin"})
+
+	args := w.transcribeArgs("/tmp/input.wav", "/tmp/transcript")
+
+	hasModel, hasInput := false, false
+	for i, arg := range args {
+		if arg == "-m" && i+1 < len(args) && args[i+1] == "/models/ggml-base.en.bin" {
+			hasModel = true
+		}
+		if arg == "-f" && i+1 < len(args) && args[i+1] == "/tmp/input.wav" {
+			hasInput = true
+		}
+	}
+
+	if !hasModel {
+		t.Error("Args missing -m flag with model path")
+	}
+	if !hasInput {
+		t.Error("Args missing -f flag with input path")
+	}
+}
+
+func TestPiperSynthesizeArgs(t *testing.T) {
+	p := NewPiperSynthesizer(PiperConfig{ModelPath: "/models/en_US-amy.onnx"})
+
+	args := p.synthesizeArgs("/tmp/out.wav")
+
+	hasModel, hasOutput := false, false
+	for i, arg := range args {
+		if arg == "-m" && i+1 < len(args) && args[i+1] == "/models/en_US-amy.onnx" {
+			hasModel = true
+		}
+		if arg == "-f" && i+1 < len(args) && args[i+1] == "/tmp/out.wav" {
+			hasOutput = true
+		}
+	}
+
+	if !hasModel {
+		t.Error("Args missing -m flag with model path")
+	}
+	if !hasOutput {
+		t.Error("Args missing -f flag with output path")
+	}
+}
+
+func TestNewWhisperTranscriberDefaults(t *testing.T) {
+	w := NewWhisperTranscriber(WhisperConfig{})
+	if w.cfg.BinPath != "whisper-cli" {
+		t.Errorf("BinPath = %s, want whisper-cli", w.cfg.BinPath)
+	}
+	if w.cfg.FFmpegPath != "ffmpeg" {
+		t.Errorf("FFmpegPath = %s, want ffmpeg", w.cfg.FFmpegPath)
+	}
+}
+
+func TestNewPiperSynthesizerDefaults(t *testing.T) {
+	p := NewPiperSynthesizer(PiperConfig{})
+	if p.cfg.BinPath != "piper" {
+		t.Errorf("BinPath = %s, want piper", p.cfg.BinPath)
+	}
+	if p.cfg.FFmpegPath != "ffmpeg" {
+		t.Errorf("FFmpegPath = %s, want ffmpeg", p.cfg.FFmpegPath)
+	}
+}
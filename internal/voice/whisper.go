@@ -0,0 +1,90 @@
+package voice
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// WhisperConfig configures the whisper.cpp subprocess Transcriber backend.
+type WhisperConfig struct {
+	BinPath    string // path to the whisper.cpp CLI binary, default "whisper-cli"
+	ModelPath  string // path to a ggml model file, e.g. ggml-base.en.bin
+	FFmpegPath string // path to ffmpeg, used to resample a voice note to the 16kHz mono WAV whisper.cpp expects; default "ffmpeg"
+}
+
+// WhisperTranscriber transcribes audio by shelling out to a local
+// whisper.cpp build. Other backends (a cloud STT API, say) only need to
+// satisfy the Transcriber interface.
+type WhisperTranscriber struct {
+	cfg WhisperConfig
+}
+
+// NewWhisperTranscriber returns a Transcriber backed by whisper.cpp,
+// filling in cfg's binary paths with their defaults if left blank.
+func NewWhisperTranscriber(cfg WhisperConfig) *WhisperTranscriber {
+	if cfg.BinPath == "" {
+		cfg.BinPath = "whisper-cli"
+	}
+	if cfg.FFmpegPath == "" {
+		cfg.FFmpegPath = "ffmpeg"
+	}
+	return &WhisperTranscriber{cfg: cfg}
+}
+
+var _ Transcriber = (*WhisperTranscriber)(nil)
+
+func (w *WhisperTranscriber) resampleArgs(inPath, outPath string) []string {
+	return []string{"-y", "-i", inPath, "-ar", "16000", "-ac", "1", outPath}
+}
+
+func (w *WhisperTranscriber) transcribeArgs(wavPath, outPrefix string) []string {
+	return []string{"-m", w.cfg.ModelPath, "-f", wavPath, "-otxt", "-of", outPrefix, "-nt"}
+}
+
+func (w *WhisperTranscriber) Transcribe(ctx context.Context, audio io.Reader) (string, error) {
+	tmpDir, err := os.MkdirTemp("", "teleclaude-voice")
+	if err != nil {
+		return "", fmt.Errorf("voice: failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	oggPath := filepath.Join(tmpDir, "input.ogg")
+	if err := writeFile(oggPath, audio); err != nil {
+		return "", fmt.Errorf("voice: failed to write voice note: %w", err)
+	}
+
+	wavPath := filepath.Join(tmpDir, "input.wav")
+	resample := exec.CommandContext(ctx, w.cfg.FFmpegPath, w.resampleArgs(oggPath, wavPath)...)
+	if out, err := resample.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("voice: ffmpeg resample failed: %w: %s", err, out)
+	}
+
+	outPrefix := filepath.Join(tmpDir, "transcript")
+	transcribe := exec.CommandContext(ctx, w.cfg.BinPath, w.transcribeArgs(wavPath, outPrefix)...)
+	if out, err := transcribe.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("voice: whisper.cpp failed: %w: %s", err, out)
+	}
+
+	text, err := os.ReadFile(outPrefix + ".txt")
+	if err != nil {
+		return "", fmt.Errorf("voice: reading transcript: %w", err)
+	}
+
+	return strings.TrimSpace(string(text)), nil
+}
+
+func writeFile(path string, r io.Reader) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, r)
+	return err
+}
@@ -0,0 +1,168 @@
+// Package xmpp implements transport.Chat over XMPP, so the bot can be
+// driven from any XMPP client (Gajim, Conversations, ...) the same way a
+// telegabber-style gateway fronts a chat network from one long-lived
+// connection rather than per-user bot tokens.
+package xmpp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/user/teleclaude/internal/transport"
+	"gosrc.io/xmpp"
+	"gosrc.io/xmpp/stanza"
+)
+
+// Config holds the connection details for the XMPP account TeleClaude logs
+// in as.
+type Config struct {
+	JID      string
+	Password string
+	Host     string
+	Port     int
+}
+
+// Chat is a transport.Chat implementation backed by a single XMPP client
+// connection. Unlike Telegram, XMPP has no native inline-keyboard concept,
+// so RequestApproval falls back to a plain-text "reply y/n" prompt.
+type Chat struct {
+	client  *xmpp.Client
+	handler func(transport.UserMessage)
+
+	mu      sync.Mutex
+	pending map[string]chan bool // recipient (bare JID) -> pending approval decision
+}
+
+var _ transport.Chat = (*Chat)(nil)
+
+// New dials no connection yet; call Start to connect and begin receiving.
+func New(cfg Config) (*Chat, error) {
+	c := &Chat{pending: make(map[string]chan bool)}
+
+	router := xmpp.NewRouter()
+	router.HandleFunc("message", c.handleMessage)
+
+	clientCfg := xmpp.Config{
+		Jid:        cfg.JID,
+		Credential: xmpp.Password(cfg.Password),
+		Address:    fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+	}
+
+	client, err := xmpp.NewClient(&clientCfg, router, func(err error) {
+		// Router-level errors (stream resets, parse failures) are logged by
+		// the client itself; nothing actionable to do here beyond letting
+		// the client's own reconnect logic take over.
+	})
+	if err != nil {
+		return nil, fmt.Errorf("xmpp: failed to create client: %w", err)
+	}
+
+	c.client = client
+	return c, nil
+}
+
+// Start implements transport.Chat, connecting and blocking until the
+// connection drops or Stop is called.
+func (c *Chat) Start() error {
+	return c.client.Connect()
+}
+
+// Stop implements transport.Chat.
+func (c *Chat) Stop() {
+	c.client.Disconnect()
+}
+
+// SendMessage implements transport.Chat, sending text to recipient's bare
+// JID.
+func (c *Chat) SendMessage(recipient, text string) error {
+	msg := stanza.Message{
+		Attrs: stanza.Attrs{To: recipient, Type: stanza.MessageTypeChat},
+		Body:  text,
+	}
+	return c.client.Send(msg)
+}
+
+// SendChunks implements transport.Chat by sending each chunk as its own
+// message stanza.
+func (c *Chat) SendChunks(recipient string, chunks []string) error {
+	for _, chunk := range chunks {
+		if err := c.SendMessage(recipient, chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RequestApproval implements transport.Chat. It sends a text prompt and
+// waits for the next message from recipient to be "y"/"yes" or "n"/"no",
+// intercepting it in handleMessage before it reaches the registered
+// OnUserMessage handler.
+func (c *Chat) RequestApproval(ctx context.Context, recipient string, req transport.ApprovalRequest) (bool, error) {
+	decision := make(chan bool, 1)
+
+	c.mu.Lock()
+	c.pending[recipient] = decision
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, recipient)
+		c.mu.Unlock()
+	}()
+
+	prompt := fmt.Sprintf("Approval needed for %s: %s\n%s\nReply y/n.", req.ToolName, req.Reason, req.Command)
+	if err := c.SendMessage(recipient, prompt); err != nil {
+		return false, err
+	}
+
+	select {
+	case approved := <-decision:
+		return approved, nil
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
+
+// OnUserMessage implements transport.Chat.
+func (c *Chat) OnUserMessage(handler func(transport.UserMessage)) {
+	c.handler = handler
+}
+
+func (c *Chat) handleMessage(s xmpp.Sender, p stanza.Packet) {
+	msg, ok := p.(stanza.Message)
+	if !ok || msg.Body == "" {
+		return
+	}
+
+	from := msg.From
+	if j, err := stanza.NewJid(msg.From); err == nil {
+		from = j.Bare()
+	}
+
+	text := strings.TrimSpace(msg.Body)
+
+	c.mu.Lock()
+	decision, awaiting := c.pending[from]
+	c.mu.Unlock()
+
+	if awaiting {
+		switch strings.ToLower(text) {
+		case "y", "yes":
+			decision <- true
+			return
+		case "n", "no":
+			decision <- false
+			return
+		}
+	}
+
+	if c.handler != nil {
+		c.handler(transport.UserMessage{
+			Transport: transport.TypeXMPP,
+			UserID:    from,
+			Recipient: from,
+			Text:      text,
+		})
+	}
+}
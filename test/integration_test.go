@@ -43,7 +43,7 @@ claude:
 	approvalWf := approval.NewWorkflow(5 * time.Minute)
 	formatter := telegram.NewFormatter(3800)
 
-	bot, err := telegram.NewBot(token, cfg, sessionMgr, approvalWf, formatter)
+	bot, err := telegram.NewBot(token, cfg, sessionMgr, approvalWf, formatter, nil)
 	if err != nil {
 		t.Fatalf("Failed to create bot: %v", err)
 	}